@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/ribeirohugo/go_config/v2/pkg/config/file"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/sample"
+)
+
+const sampleFileMode = 0o600
+
+// runInit writes a sample config file, documented with field descriptions and default values.
+func runInit(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("init", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "", "sample format: toml, yaml, json or env (default from file extension, or toml)")
+	force := flags.Bool("force", false, "overwrite an existing file")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		_, _ = fmt.Fprintln(stderr, "usage: goconfig init [-format toml|yaml|json|env] [-force] [file]")
+		return exitUsage
+	}
+	filePath := flags.Arg(0)
+
+	if *format == "" {
+		*format = file.FormatTOML
+		if filePath != "" {
+			fileFormat, err := file.Format(filePath)
+			if err != nil {
+				_, _ = fmt.Fprintln(stderr, err)
+				return exitUsage
+			}
+			*format = fileFormat
+		}
+	}
+
+	content, err := sample.Generate(*format)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	if filePath == "" {
+		_, _ = stdout.Write(content)
+		return exitOK
+	}
+
+	if !*force {
+		_, err = os.Stat(filePath)
+		if err == nil {
+			_, _ = fmt.Fprintf(stderr, "%s: file already exists, use -force to overwrite it\n", filePath)
+			return exitFailure
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			_, _ = fmt.Fprintln(stderr, err)
+			return exitFailure
+		}
+	}
+
+	err = os.WriteFile(filePath, content, sampleFileMode)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s: created\n", filePath)
+	return exitOK
+}
@@ -0,0 +1,58 @@
+// Command goconfig validates, inspects and generates go_config configuration files.
+//
+// Usage:
+//
+//	goconfig validate [-strict] <file>
+//	goconfig print [-format toml|yaml|json|xml] [file]
+//	goconfig init [-format toml|yaml|json|env] [-force] [file]
+//	goconfig schema [file]
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+const usage = `Usage: goconfig <command> [arguments]
+
+Commands:
+  validate [-strict] <file>                    validate a config file
+  print [-format toml|yaml|json|xml] [file]    print the effective config, with secrets redacted
+  init [-format toml|yaml|json|env] [file]     write a documented sample config
+  schema [file]                                write the JSON Schema of config files
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "validate":
+		return runValidate(args[1:], stdout, stderr)
+	case "print":
+		return runPrint(args[1:], stdout, stderr)
+	case "init":
+		return runInit(args[1:], stdout, stderr)
+	case "schema":
+		return runSchema(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		_, _ = fmt.Fprint(stdout, usage)
+		return exitOK
+	}
+
+	_, _ = fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+	return exitUsage
+}
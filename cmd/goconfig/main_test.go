@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const configContent = `environment = "dev"
+
+[server]
+host = "localhost"
+port = 8080
+
+[postgres]
+host = "localhost"
+password = "password"
+`
+
+const configContentMissingFields = `[server]
+port = 70000
+`
+
+func TestRun(t *testing.T) {
+	t.Run("should print usage without command", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := run(nil, &stdout, &stderr)
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr.String(), "Usage: goconfig")
+	})
+
+	t.Run("should return an error for unknown commands", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{"unknown"}, &stdout, &stderr)
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr.String(), `unknown command "unknown"`)
+	})
+}
+
+func TestRunValidate(t *testing.T) {
+	t.Run("should succeed with a valid file", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		filePath := createTempFile(t, "config.toml", configContent)
+
+		code := run([]string{"validate", filePath}, &stdout, &stderr)
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, stdout.String(), filePath+": ok")
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("should report every invalid field", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		filePath := createTempFile(t, "config.toml", configContentMissingFields)
+
+		code := run([]string{"validate", filePath}, &stdout, &stderr)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr.String(), filePath+": server.host: is required")
+		assert.Contains(t, stderr.String(), filePath+": server.port: invalid port 70000")
+	})
+
+	t.Run("should report decoding errors", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		filePath := createTempFile(t, "config.yml", "server:\n  port: [\n")
+
+		code := run([]string{"validate", filePath}, &stdout, &stderr)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr.String(), filePath+":2: yaml: did not find expected node content")
+	})
+
+	t.Run("should report unknown keys in strict mode", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		filePath := createTempFile(t, "config.toml", configContent+"pasword = \"password\"\n")
+
+		code := run([]string{"validate", filePath}, &stdout, &stderr)
+		assert.Equal(t, exitOK, code)
+
+		code = run([]string{"validate", "-strict", filePath}, &stdout, &stderr)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr.String(), filePath+": postgres.pasword: unknown config key")
+	})
+
+	t.Run("should require a file argument", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{"validate"}, &stdout, &stderr)
+		assert.Equal(t, exitUsage, code)
+	})
+}
+
+func TestRunPrint(t *testing.T) {
+	t.Run("should print the effective config with redacted secrets", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		filePath := createTempFile(t, "config.toml", configContent)
+		t.Setenv("SERVER_PORT", "9090")
+
+		code := run([]string{"print", "-format", "json", filePath}, &stdout, &stderr)
+		require.Equal(t, exitOK, code, stderr.String())
+		assert.Contains(t, stdout.String(), `"port": 9090`)
+		assert.Contains(t, stdout.String(), `"password": "[REDACTED]"`)
+		assert.Contains(t, stdout.String(), `"migrations_path": "file://migrations/postgres"`)
+		assert.NotContains(t, stdout.String(), `"password": "password"`)
+	})
+
+	t.Run("should print in every supported format", func(t *testing.T) {
+		filePath := createTempFile(t, "config.toml", configContent)
+
+		for _, format := range []string{"toml", "yaml", "json", "xml"} {
+			var stdout, stderr bytes.Buffer
+
+			code := run([]string{"print", "-format", format, filePath}, &stdout, &stderr)
+			assert.Equal(t, exitOK, code, format)
+			assert.Contains(t, stdout.String(), "localhost", format)
+		}
+	})
+
+	t.Run("should return an error for unsupported formats", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{"print", "-format", "ini"}, &stdout, &stderr)
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr.String(), "unsupported output format: ini")
+	})
+}
+
+func TestRunInit(t *testing.T) {
+	t.Run("should write a sample with the file extension format", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		filePath := filepath.Join(t.TempDir(), "config.yml")
+
+		code := run([]string{"init", filePath}, &stdout, &stderr)
+		require.Equal(t, exitOK, code, stderr.String())
+
+		content, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "# HTTP server configuration.\nserver:\n")
+	})
+
+	t.Run("should print a toml sample without file", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{"init"}, &stdout, &stderr)
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, stdout.String(), "[postgres]\n")
+	})
+
+	t.Run("should not overwrite existing files without force", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		filePath := createTempFile(t, "config.toml", configContent)
+
+		code := run([]string{"init", filePath}, &stdout, &stderr)
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr.String(), "file already exists")
+
+		code = run([]string{"init", "-force", filePath}, &stdout, &stderr)
+		assert.Equal(t, exitOK, code)
+	})
+}
+
+func TestRunSchema(t *testing.T) {
+	t.Run("should write the schema to a file", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		filePath := filepath.Join(t.TempDir(), "config.schema.json")
+
+		code := run([]string{"schema", filePath}, &stdout, &stderr)
+		require.Equal(t, exitOK, code, stderr.String())
+
+		content, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `"$schema": "https://json-schema.org/draft/2020-12/schema"`)
+	})
+
+	t.Run("should print the schema without file", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{"schema"}, &stdout, &stderr)
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, stdout.String(), `"properties"`)
+	})
+}
+
+func createTempFile(t *testing.T, fileName string, fileContent string) string {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), fileName)
+	err := os.WriteFile(filePath, []byte(fileContent), 0o600)
+	require.NoError(t, err)
+
+	return filePath
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ribeirohugo/go_config/v2/pkg/config"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/env"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/file"
+)
+
+// runPrint prints the effective configuration, after defaults and environment overrides.
+func runPrint(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("print", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", file.FormatYAML, "output format: toml, yaml, json or xml")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		_, _ = fmt.Fprintln(stderr, "usage: goconfig print [-format toml|yaml|json|xml] [file]")
+		return exitUsage
+	}
+
+	cfg, err := loadEffective(flags.Arg(0))
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+
+	content, err := encode(cfg.Redacted(), *format)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	_, _ = stdout.Write(content)
+	return exitOK
+}
+
+// loadEffective loads a config file, when given, and applies environment overrides on top of it.
+func loadEffective(filePath string) (config.Config, error) {
+	if filePath == "" {
+		return env.Load()
+	}
+
+	cfg, err := file.Load(filePath)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	return env.Override(cfg)
+}
+
+func encode(cfg config.Config, format string) ([]byte, error) {
+	switch format {
+	case file.FormatTOML:
+		var buf bytes.Buffer
+		err := toml.NewEncoder(&buf).Encode(cfg)
+		return buf.Bytes(), err
+	case file.FormatYAML:
+		return yaml.Marshal(cfg)
+	case file.FormatJSON:
+		content, err := json.MarshalIndent(cfg, "", "  ")
+		return append(content, '\n'), err
+	case file.FormatXML:
+		content, err := xml.MarshalIndent(config.XML{Config: cfg}, "", "  ")
+		return append(content, '\n'), err
+	}
+	return nil, fmt.Errorf("unsupported output format: %s", format)
+}
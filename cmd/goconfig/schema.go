@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ribeirohugo/go_config/v2/pkg/config/schema"
+)
+
+const schemaFileMode = 0o644
+
+// runSchema writes the JSON Schema of config files to a given path, or to stdout.
+func runSchema(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("schema", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		_, _ = fmt.Fprintln(stderr, "usage: goconfig schema [file]")
+		return exitUsage
+	}
+	filePath := flags.Arg(0)
+
+	content, err := schema.Generate()
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+
+	if filePath == "" {
+		_, _ = stdout.Write(content)
+		return exitOK
+	}
+
+	err = os.WriteFile(filePath, content, schemaFileMode)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s: created\n", filePath)
+	return exitOK
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ribeirohugo/go_config/v2/pkg/config"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/file"
+)
+
+// runValidate loads a config file and reports decoding and validation errors.
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	strict := flags.Bool("strict", false, "report keys not matching any config field")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		_, _ = fmt.Fprintln(stderr, "usage: goconfig validate [-strict] <file>")
+		return exitUsage
+	}
+	filePath := flags.Arg(0)
+
+	load := file.Load
+	if *strict {
+		load = file.LoadStrict
+	}
+
+	cfg, err := load(filePath)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		var validationErrs config.ValidationErrors
+		if !errors.As(err, &validationErrs) {
+			_, _ = fmt.Fprintf(stderr, "%s: %s\n", filePath, err)
+			return exitFailure
+		}
+		for _, fieldErr := range validationErrs {
+			_, _ = fmt.Fprintf(stderr, "%s: %s\n", filePath, fieldErr)
+		}
+		return exitFailure
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s: ok\n", filePath)
+	return exitOK
+}
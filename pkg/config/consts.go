@@ -1,5 +1,15 @@
 package config
 
+// Database drivers of named databases.
+const (
+	DriverClickHouse = "clickhouse"
+	DriverMongoDB    = "mongodb"
+	DriverMySQL      = "mysql"
+	DriverPostgres   = "postgres"
+	DriverSQLite     = "sqlite"
+	DriverSQLServer  = "sqlserver"
+)
+
 const (
 	DefaultMigrationsMongo    = "file://migrations/mongo"
 	DefaultMigrationsMysql    = "file://migrations/mysql"
@@ -7,9 +17,63 @@ const (
 	DefaultMongoPort          = 27017
 	DefaultMySQLPort          = 3306
 	DefaultPostgresPort       = 5432
-	DefaultSessionMaxAge      = 86400 // 24 hours
+	DefaultSessionMaxAge      = 86400  // 24 hours, in seconds
+	DefaultRefreshMaxAge      = 604800 // 7 days, in seconds
+	DefaultTokenAlgorithm     = TokenHS256
 	DefaultJaegerHost         = "http://localhost:14268/api/traces"
 	DefaultLokiHost           = "http://localhost:3100/loki/api/v1/push"
 	DefaultTempoHost          = "http://localhost:4318/v1/traces"
 	DefaultRedisHost          = "localhost:6379"
+	DefaultRedisDialTimeout   = 5 // seconds
+	DefaultRedisReadTimeout   = 3 // seconds
+	DefaultRedisWriteTimeout  = 3 // seconds
+
+	DefaultMigrationsClickHouse = "file://migrations/clickhouse"
+	DefaultMigrationsSQLite     = "file://migrations/sqlite"
+	DefaultMigrationsSQLServer  = "file://migrations/sqlserver"
+	DefaultClickHousePort       = 9000
+	DefaultSQLServerPort        = 1433
+
+	DefaultKafkaBroker        = "localhost:9092"
+	DefaultKafkaDialTimeout   = 10 // seconds
+	DefaultKafkaSASLMechanism = "PLAIN"
+	DefaultNATSURL            = "nats://localhost:4222"
+	DefaultNATSConnectTimeout = 2 // seconds
+	DefaultRabbitMQHost       = "localhost"
+	DefaultRabbitMQPort       = 5672
+	DefaultRabbitMQVHost      = "/"
+	DefaultRabbitMQHeartbeat  = 10 // seconds
+
+	DefaultOTelEndpoint        = "http://localhost:4318"
+	DefaultOTelProtocol        = OTelProtocolHTTPProtobuf
+	DefaultOTelExporter        = OTelExporterOTLP
+	DefaultOTelSampleRatio     = 1.0
+	DefaultOTelTimeout         = 10 // seconds
+	DefaultOTelMetricsInterval = 60 // seconds
+
+	DefaultExternalServiceTimeout = 10 // seconds
+
+	DefaultLokiBatchSize = 1 << 20 // bytes
+	DefaultLokiBatchWait = 1       // seconds
+	DefaultLokiTimeout   = 10      // seconds
+
+	DefaultPrometheusPort = 2112
+	DefaultPrometheusPath = "/metrics"
+
+	DefaultLogLevel  = "info"
+	DefaultLogFormat = LogFormatText
+	DefaultLogOutput = LogOutputStdout
+
+	DefaultDatabaseMaxOpenConns    = 25
+	DefaultDatabaseMaxIdleConns    = 5
+	DefaultDatabaseConnMaxLifetime = 1800 // 30 minutes, in seconds
+	DefaultDatabaseConnMaxIdleTime = 300  // 5 minutes, in seconds
+
+	DefaultServerReadTimeout       = 15 // seconds
+	DefaultServerReadHeaderTimeout = 5  // seconds
+	DefaultServerWriteTimeout      = 15 // seconds
+	DefaultServerIdleTimeout       = 60 // seconds
+	DefaultServerShutdownTimeout   = 30 // seconds
+	DefaultServerMaxHeaderBytes    = 1 << 20
+	DefaultServerTLSMinVersion     = "1.2"
 )
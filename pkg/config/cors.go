@@ -0,0 +1,156 @@
+package config
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const wildcard = "*"
+
+var defaultCORSMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+
+// defaultCORSHeaders are the request headers allowed without AllowedHeaders, for JSON and authenticated requests.
+var defaultCORSHeaders = []string{"Accept", "Accept-Language", "Authorization", "Content-Language", "Content-Type"}
+
+// AllowsOrigin reports whether an origin matches AllowedOrigins.
+// Origins may hold a single wildcard, as in https://*.example.com, and * allows any origin.
+func (s Server) AllowsOrigin(origin string) bool {
+	if origin == "" {
+		return false
+	}
+
+	origin = strings.ToLower(origin)
+	for _, allowed := range s.AllowedOrigins {
+		if matchOrigin(strings.ToLower(strings.TrimSpace(allowed)), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORSHandler returns a middleware enforcing the CORS policy for AllowedOrigins.
+// Preflight requests from allowed origins are answered without calling next.
+func (s Server) CORSHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		w.Header().Add("Vary", "Origin")
+		if preflight {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if !s.AllowsOrigin(origin) {
+			if preflight {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if preflight {
+			s.CORS.preflight(w, r)
+			return
+		}
+
+		s.CORS.setOrigin(w, origin)
+		if len(s.CORS.ExposedHeaders) > 0 {
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(s.CORS.ExposedHeaders, ", "))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (c CORS) preflight(w http.ResponseWriter, r *http.Request) {
+	method := r.Header.Get("Access-Control-Request-Method")
+	methods := c.methods()
+	if !slices.Contains(methods, method) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	requestHeaders := parseHeaderList(r.Header.Get("Access-Control-Request-Headers"))
+	if !c.allowsHeaders(requestHeaders) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	c.setOrigin(w, r.Header.Get("Origin"))
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	if len(requestHeaders) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(requestHeaders, ", "))
+	}
+	if c.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(c.MaxAge.Duration().Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c CORS) setOrigin(w http.ResponseWriter, origin string) {
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if c.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (c CORS) methods() []string {
+	if len(c.AllowedMethods) == 0 {
+		return defaultCORSMethods
+	}
+
+	methods := make([]string, 0, len(c.AllowedMethods))
+	for _, method := range c.AllowedMethods {
+		methods = append(methods, strings.ToUpper(strings.TrimSpace(method)))
+	}
+	return methods
+}
+
+func (c CORS) allowsHeaders(headers []string) bool {
+	allowedHeaders := c.AllowedHeaders
+	if len(allowedHeaders) == 0 {
+		allowedHeaders = defaultCORSHeaders
+	}
+
+	for _, header := range headers {
+		allowed := slices.ContainsFunc(allowedHeaders, func(allowed string) bool {
+			allowed = strings.TrimSpace(allowed)
+			return allowed == wildcard || strings.EqualFold(allowed, header)
+		})
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// matchOrigin matches an origin against a pattern holding at most one wildcard.
+// The wildcard matches a non-empty value without path separators, as a subdomain or port.
+func matchOrigin(pattern string, origin string) bool {
+	if pattern == wildcard {
+		return true
+	}
+
+	prefix, suffix, found := strings.Cut(pattern, wildcard)
+	if !found {
+		return pattern == origin
+	}
+	if len(origin) <= len(prefix)+len(suffix) || !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+		return false
+	}
+
+	return !strings.Contains(origin[len(prefix):len(origin)-len(suffix)], "/")
+}
+
+func parseHeaderList(value string) []string {
+	var headers []string
+	for _, header := range strings.Split(value, ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			headers = append(headers, http.CanonicalHeaderKey(header))
+		}
+	}
+	return headers
+}
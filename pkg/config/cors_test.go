@@ -0,0 +1,163 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_AllowsOrigin(t *testing.T) {
+	server := Server{
+		AllowedOrigins: []string{"http://localhost:8080", "https://*.example.com", "http://127.0.0.1:*"},
+	}
+
+	tests := map[string]bool{
+		"http://localhost:8080":         true,
+		"HTTP://LOCALHOST:8080":         true,
+		"https://api.example.com":       true,
+		"https://a.b.example.com":       true,
+		"http://127.0.0.1:3000":         true,
+		"https://example.com":           false,
+		"https://.example.com":          false,
+		"http://api.example.com":        false,
+		"https://evil.com/.example.com": false,
+		"https://api.example.com.evil":  false,
+		"http://localhost:9090":         false,
+		"":                              false,
+	}
+
+	for origin, expected := range tests {
+		assert.Equal(t, expected, server.AllowsOrigin(origin), origin)
+	}
+
+	t.Run("should allow any origin with wildcard", func(t *testing.T) {
+		assert.True(t, Server{AllowedOrigins: []string{"*"}}.AllowsOrigin("https://any.domain"))
+	})
+}
+
+func TestServer_CORSHandler(t *testing.T) {
+	server := Server{
+		AllowedOrigins: []string{"https://*.example.com"},
+		CORS: CORS{
+			AllowedMethods:   []string{"GET", "PUT"},
+			AllowedHeaders:   []string{"Content-Type", "Authorization"},
+			ExposedHeaders:   []string{"X-Request-Id"},
+			AllowCredentials: true,
+			MaxAge:           Duration(10 * time.Minute),
+		},
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := server.CORSHandler(next)
+
+	t.Run("should set CORS headers for allowed origins", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Origin", "https://app.example.com")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusTeapot, recorder.Code)
+		assert.Equal(t, "https://app.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "X-Request-Id", recorder.Header().Get("Access-Control-Expose-Headers"))
+		assert.Equal(t, "Origin", recorder.Header().Get("Vary"))
+	})
+
+	t.Run("should not set CORS headers for other origins", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Origin", "https://other.domain")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusTeapot, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("should answer allowed preflight requests", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodOptions, "/", nil)
+		request.Header.Set("Origin", "https://app.example.com")
+		request.Header.Set("Access-Control-Request-Method", "PUT")
+		request.Header.Set("Access-Control-Request-Headers", "content-type, authorization")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Equal(t, "https://app.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, PUT", recorder.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type, Authorization", recorder.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", recorder.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("should reject preflight requests", func(t *testing.T) {
+		tests := map[string]struct {
+			origin  string
+			method  string
+			headers string
+		}{
+			"with other origin":  {origin: "https://other.domain", method: "GET"},
+			"with other method":  {origin: "https://app.example.com", method: "DELETE"},
+			"with other headers": {origin: "https://app.example.com", method: "GET", headers: "X-Custom"},
+		}
+
+		for name, test := range tests {
+			request := httptest.NewRequest(http.MethodOptions, "/", nil)
+			request.Header.Set("Origin", test.origin)
+			request.Header.Set("Access-Control-Request-Method", test.method)
+			request.Header.Set("Access-Control-Request-Headers", test.headers)
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			assert.Equal(t, http.StatusForbidden, recorder.Code, name)
+			assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"), name)
+		}
+	})
+
+	t.Run("should allow simple methods and any header by default", func(t *testing.T) {
+		server := Server{
+			AllowedOrigins: []string{"*"},
+			CORS:           CORS{AllowedHeaders: []string{"*"}},
+		}
+		request := httptest.NewRequest(http.MethodOptions, "/", nil)
+		request.Header.Set("Origin", "https://any.domain")
+		request.Header.Set("Access-Control-Request-Method", "POST")
+		request.Header.Set("Access-Control-Request-Headers", "X-Custom")
+		recorder := httptest.NewRecorder()
+
+		server.CORSHandler(next).ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Equal(t, "GET, HEAD, POST", recorder.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "X-Custom", recorder.Header().Get("Access-Control-Allow-Headers"))
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Credentials"))
+	})
+	t.Run("should allow common headers without allowed headers", func(t *testing.T) {
+		server := Server{AllowedOrigins: []string{"*"}}
+		handler := server.CORSHandler(next)
+
+		request := httptest.NewRequest(http.MethodOptions, "/", nil)
+		request.Header.Set("Origin", "https://any.domain")
+		request.Header.Set("Access-Control-Request-Method", "POST")
+		request.Header.Set("Access-Control-Request-Headers", "content-type, authorization")
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Equal(t, "Content-Type, Authorization", recorder.Header().Get("Access-Control-Allow-Headers"))
+
+		request.Header.Set("Access-Control-Request-Headers", "X-Custom")
+		recorder = httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
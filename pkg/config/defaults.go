@@ -0,0 +1,120 @@
+package config
+
+import "time"
+
+// Default returns a configuration holding the default values from consts.go.
+// Loaders start from it, so fields missing from a source keep these values.
+func Default() Config {
+	return Config{
+		Server: Server{
+			ReadTimeout:       Duration(DefaultServerReadTimeout * time.Second),
+			ReadHeaderTimeout: Duration(DefaultServerReadHeaderTimeout * time.Second),
+			WriteTimeout:      Duration(DefaultServerWriteTimeout * time.Second),
+			IdleTimeout:       Duration(DefaultServerIdleTimeout * time.Second),
+			ShutdownTimeout:   Duration(DefaultServerShutdownTimeout * time.Second),
+			MaxHeaderBytes:    DefaultServerMaxHeaderBytes,
+			TLSMinVersion:     DefaultServerTLSMinVersion,
+		},
+		MySql: Database{
+			Port:            DefaultMySQLPort,
+			MigrationsPath:  DefaultMigrationsMysql,
+			MaxOpenConns:    DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: Duration(DefaultDatabaseConnMaxLifetime * time.Second),
+			ConnMaxIdleTime: Duration(DefaultDatabaseConnMaxIdleTime * time.Second),
+		},
+		MongoDb: Database{
+			Port:           DefaultMongoPort,
+			MigrationsPath: DefaultMigrationsMongo,
+		},
+		Postgres: Database{
+			Port:            DefaultPostgresPort,
+			MigrationsPath:  DefaultMigrationsPostgres,
+			MaxOpenConns:    DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: Duration(DefaultDatabaseConnMaxLifetime * time.Second),
+			ConnMaxIdleTime: Duration(DefaultDatabaseConnMaxIdleTime * time.Second),
+		},
+		SQLite: Database{
+			MigrationsPath: DefaultMigrationsSQLite,
+		},
+		SQLServer: Database{
+			Port:            DefaultSQLServerPort,
+			MigrationsPath:  DefaultMigrationsSQLServer,
+			MaxOpenConns:    DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: Duration(DefaultDatabaseConnMaxLifetime * time.Second),
+			ConnMaxIdleTime: Duration(DefaultDatabaseConnMaxIdleTime * time.Second),
+		},
+		ClickHouse: Database{
+			Port:            DefaultClickHousePort,
+			MigrationsPath:  DefaultMigrationsClickHouse,
+			MaxOpenConns:    DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: Duration(DefaultDatabaseConnMaxLifetime * time.Second),
+			ConnMaxIdleTime: Duration(DefaultDatabaseConnMaxIdleTime * time.Second),
+		},
+		Token: Token{
+			MaxAge:        Duration(DefaultSessionMaxAge * time.Second),
+			Algorithm:     DefaultTokenAlgorithm,
+			RefreshMaxAge: Duration(DefaultRefreshMaxAge * time.Second),
+		},
+		Audit: ExternalService{
+			Timeout: Duration(DefaultExternalServiceTimeout * time.Second),
+		},
+		Loki: Loki{
+			Host:      DefaultLokiHost,
+			BatchSize: DefaultLokiBatchSize,
+			BatchWait: Duration(DefaultLokiBatchWait * time.Second),
+			Timeout:   Duration(DefaultLokiTimeout * time.Second),
+		},
+		Tempo: ExternalService{
+			Host:    DefaultTempoHost,
+			Timeout: Duration(DefaultExternalServiceTimeout * time.Second),
+		},
+		Jaeger: ExternalService{
+			Host:    DefaultJaegerHost,
+			Timeout: Duration(DefaultExternalServiceTimeout * time.Second),
+		},
+		Prometheus: Prometheus{
+			Port: DefaultPrometheusPort,
+			Path: DefaultPrometheusPath,
+		},
+		Redis: Redis{
+			Host:         DefaultRedisHost,
+			DialTimeout:  Duration(DefaultRedisDialTimeout * time.Second),
+			ReadTimeout:  Duration(DefaultRedisReadTimeout * time.Second),
+			WriteTimeout: Duration(DefaultRedisWriteTimeout * time.Second),
+		},
+		OTel: OTel{
+			SampleRatio:     DefaultOTelSampleRatio,
+			MetricsInterval: Duration(DefaultOTelMetricsInterval * time.Second),
+			Endpoint:        DefaultOTelEndpoint,
+			Protocol:        DefaultOTelProtocol,
+			Timeout:         Duration(DefaultOTelTimeout * time.Second),
+			Traces:          OTelExporter{Exporter: DefaultOTelExporter},
+			Metrics:         OTelExporter{Exporter: DefaultOTelExporter},
+			Logs:            OTelExporter{Exporter: DefaultOTelExporter},
+		},
+		Log: Logging{
+			Level:  DefaultLogLevel,
+			Format: DefaultLogFormat,
+			Output: DefaultLogOutput,
+		},
+		Kafka: Kafka{
+			DialTimeout: Duration(DefaultKafkaDialTimeout * time.Second),
+			SASL: SASL{
+				Mechanism: DefaultKafkaSASLMechanism,
+			},
+		},
+		NATS: NATS{
+			ConnectTimeout: Duration(DefaultNATSConnectTimeout * time.Second),
+		},
+		RabbitMQ: RabbitMQ{
+			Host:      DefaultRabbitMQHost,
+			Port:      DefaultRabbitMQPort,
+			VHost:     DefaultRabbitMQVHost,
+			Heartbeat: Duration(DefaultRabbitMQHeartbeat * time.Second),
+		},
+	}
+}
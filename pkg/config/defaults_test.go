@@ -0,0 +1,127 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefault(t *testing.T) {
+	expectedConfig := Config{
+		Server: Server{
+			ReadTimeout:       Duration(15 * time.Second),
+			ReadHeaderTimeout: Duration(5 * time.Second),
+			WriteTimeout:      Duration(15 * time.Second),
+			IdleTimeout:       Duration(time.Minute),
+			ShutdownTimeout:   Duration(30 * time.Second),
+			MaxHeaderBytes:    1048576,
+			TLSMinVersion:     "1.2",
+		},
+		MySql: Database{
+			Port:            3306,
+			MigrationsPath:  "file://migrations/mysql",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: Duration(30 * time.Minute),
+			ConnMaxIdleTime: Duration(5 * time.Minute),
+		},
+		MongoDb: Database{
+			Port:           27017,
+			MigrationsPath: "file://migrations/mongo",
+		},
+		Postgres: Database{
+			Port:            5432,
+			MigrationsPath:  "file://migrations/postgres",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: Duration(30 * time.Minute),
+			ConnMaxIdleTime: Duration(5 * time.Minute),
+		},
+		SQLite: Database{
+			MigrationsPath: "file://migrations/sqlite",
+		},
+		SQLServer: Database{
+			Port:            1433,
+			MigrationsPath:  "file://migrations/sqlserver",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: Duration(30 * time.Minute),
+			ConnMaxIdleTime: Duration(5 * time.Minute),
+		},
+		ClickHouse: Database{
+			Port:            9000,
+			MigrationsPath:  "file://migrations/clickhouse",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: Duration(30 * time.Minute),
+			ConnMaxIdleTime: Duration(5 * time.Minute),
+		},
+		Token: Token{
+			MaxAge:        Duration(24 * time.Hour),
+			Algorithm:     "HS256",
+			RefreshMaxAge: Duration(7 * 24 * time.Hour),
+		},
+		Audit: ExternalService{
+			Timeout: Duration(10 * time.Second),
+		},
+		Loki: Loki{
+			Host:      "http://localhost:3100/loki/api/v1/push",
+			BatchSize: 1 << 20,
+			BatchWait: Duration(time.Second),
+			Timeout:   Duration(10 * time.Second),
+		},
+		Tempo: ExternalService{
+			Host:    "http://localhost:4318/v1/traces",
+			Timeout: Duration(10 * time.Second),
+		},
+		Jaeger: ExternalService{
+			Host:    "http://localhost:14268/api/traces",
+			Timeout: Duration(10 * time.Second),
+		},
+		Prometheus: Prometheus{
+			Port: 2112,
+			Path: "/metrics",
+		},
+		Redis: Redis{
+			Host:         "localhost:6379",
+			DialTimeout:  Duration(5 * time.Second),
+			ReadTimeout:  Duration(3 * time.Second),
+			WriteTimeout: Duration(3 * time.Second),
+		},
+		OTel: OTel{
+			SampleRatio:     1,
+			MetricsInterval: Duration(time.Minute),
+			Endpoint:        "http://localhost:4318",
+			Protocol:        "http/protobuf",
+			Timeout:         Duration(10 * time.Second),
+			Traces:          OTelExporter{Exporter: "otlp"},
+			Metrics:         OTelExporter{Exporter: "otlp"},
+			Logs:            OTelExporter{Exporter: "otlp"},
+		},
+		Log: Logging{
+			Level:  "info",
+			Format: "text",
+			Output: "stdout",
+		},
+		Kafka: Kafka{
+			DialTimeout: Duration(10 * time.Second),
+			SASL: SASL{
+				Mechanism: "PLAIN",
+			},
+		},
+		NATS: NATS{
+			ConnectTimeout: Duration(2 * time.Second),
+		},
+		RabbitMQ: RabbitMQ{
+			Host:      "localhost",
+			Port:      5672,
+			VHost:     "/",
+			Heartbeat: Duration(10 * time.Second),
+		},
+	}
+
+	t.Run("should return default values", func(t *testing.T) {
+		assert.Equal(t, expectedConfig, Default())
+	})
+}
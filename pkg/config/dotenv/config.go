@@ -1,18 +1,27 @@
 package dotenv
 
 import (
+	"errors"
+
 	"github.com/joho/godotenv"
 
 	"github.com/ribeirohugo/go_config/v2/pkg/config"
 	"github.com/ribeirohugo/go_config/v2/pkg/config/env"
 )
 
-// Load loads configurations from a given toml file path.
+// Load loads configurations from a given dotenv file path, applied over the OS environment.
+// Errors of invalid values hold the file path as source, along with the variable name.
 func Load(filePath string) (config.Config, error) {
 	err := godotenv.Load(filePath)
 	if err != nil {
-		return config.Config{}, err
+		return config.Config{}, &config.Error{Source: filePath, Err: err}
+	}
+
+	cfg, err := env.Load()
+	var cfgErr *config.Error
+	if errors.As(err, &cfgErr) {
+		return config.Config{}, &config.Error{Source: filePath, Key: cfgErr.Key, Err: cfgErr.Err}
 	}
 
-	return env.Load()
+	return cfg, err
 }
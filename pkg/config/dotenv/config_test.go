@@ -3,6 +3,7 @@ package dotenv
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,8 +17,30 @@ SERVICE=safesystem
 SERVER_HOST=localhost
 SERVER_PORT=8080
 SERVER_ALLOWED_ORIGINS=http://localhost:8080
+SERVER_CORS_ALLOWED_METHODS=GET,POST
+SERVER_CORS_ALLOWED_HEADERS=Content-Type,Authorization
+SERVER_CORS_EXPOSED_HEADERS=X-Request-Id
+SERVER_CORS_ALLOW_CREDENTIALS=true
+SERVER_CORS_MAX_AGE=10m
+SERVER_TLS_CERT_FILE=/etc/ssl/server.crt
+SERVER_TLS_KEY_FILE=/etc/ssl/server.key
+SERVER_TLS_MIN_VERSION=1.3
+SERVER_READ_TIMEOUT=10s
+SERVER_READ_HEADER_TIMEOUT=2
+SERVER_WRITE_TIMEOUT=20s
+SERVER_IDLE_TIMEOUT=2m
+SERVER_SHUTDOWN_TIMEOUT=45s
+SERVER_MAX_HEADER_BYTES=4096
 
 TOKEN_SECRET=token
+TOKEN_ALGORITHM=RS256
+TOKEN_ISSUER=https://auth.domain
+TOKEN_AUDIENCE=orders,payments
+TOKEN_REFRESH_MAX_AGE=72h
+TOKEN_KEY_ID=2024-06
+TOKEN_PRIVATE_KEY_FILE=/etc/keys/token.pem
+TOKEN_PUBLIC_KEY_FILE=/etc/keys/token.pub
+TOKEN_VERIFICATION_KEY_FILES=2024-01=/etc/keys/2024-01.pub
 MAX_AGE=100
 
 MONGODB_DATABASE=database
@@ -25,30 +48,71 @@ MONGODB_HOST=localhost
 MONGODB_PASSWORD=password
 MONGODB_PORT=8080
 MONGODB_USER=username
+MONGODB_REPLICA_SET=rs0
+MONGODB_OPTIONS=retryWrites=true
 
 MYSQL_DATABASE=database
 MYSQL_HOST=localhost
 MYSQL_PASSWORD=password
 MYSQL_PORT=8080
 MYSQL_USER=username
+MYSQL_SOCKET=/var/run/mysqld/mysqld.sock
 
 POSTGRES_DATABASE=database
 POSTGRES_HOST=localhost
 POSTGRES_PASSWORD=password
 POSTGRES_PORT=8080
 POSTGRES_USER=username
+POSTGRES_MAX_OPEN_CONNS=50
+POSTGRES_MAX_IDLE_CONNS=10
+POSTGRES_CONN_MAX_LIFETIME=1h
+POSTGRES_CONN_MAX_IDLE_TIME=10m
+POSTGRES_REPLICAS=replica1.domain,reader:reader.password@replica2.domain:5433
+
+SQLITE_PATH=/var/lib/app/app.db
+SQLITE_PRAGMAS=journal_mode=WAL,busy_timeout=5000
+
+SQLSERVER_HOST=sqlserver.domain
+SQLSERVER_USER=username
+SQLSERVER_PASSWORD=password
+SQLSERVER_DATABASE=database
+
+CLICKHOUSE_HOST=clickhouse.domain
+CLICKHOUSE_DATABASE=analytics
+
+DATABASES_ANALYTICS_DRIVER=postgres
+DATABASES_ANALYTICS_HOST=analytics.domain
+DATABASES_ANALYTICS_PORT=5433
+DATABASES_ANALYTICS_USER=username
+DATABASES_ANALYTICS_PASSWORD=password
+DATABASES_ANALYTICS_DATABASE=analytics
 
 AUDIT_ENABLED=true
 AUDIT_HOST=audit.domain
 AUDIT_TOKEN=audit.token
+AUDIT_TIMEOUT=5s
+AUDIT_RETRIES=3
+AUDIT_HEADERS=X-Source=orders
+AUDIT_TLS_CA_FILE=ca.crt
 
 LOKI_ENABLED=true
 LOKI_HOST=loki.domain
 LOKI_TOKEN=loki.token
+LOKI_TENANT_ID=shop
+LOKI_LABELS=team=payments
+LOKI_BATCH_SIZE=524288
+LOKI_BATCH_WAIT=2s
 
 PROMETHEUS_ENABLED=true
 PROMETHEUS_HOST=prometheus.domain
+PROMETHEUS_PORT=9100
+PROMETHEUS_PATH=/internal/metrics
+PROMETHEUS_NAMESPACE=shop
+PROMETHEUS_SUBSYSTEM=orders
+PROMETHEUS_BASIC_AUTH_USERNAME=prometheus.user
+PROMETHEUS_BASIC_AUTH_PASSWORD=prometheus.password
 PROMETHEUS_TOKEN=prometheus.token
+PROMETHEUS_PUSHGATEWAY_URL=http://pushgateway.domain:9091
 
 TEMPO_ENABLED=true
 TEMPO_HOST=tempo.domain
@@ -61,6 +125,59 @@ JAEGER_TOKEN=jaeger.token
 REDIS_ENABLED=true
 REDIS_HOST=redis.domain
 REDIS_TOKEN=redis.token
+REDIS_USERNAME=redis.user
+REDIS_PASSWORD=redis.password
+REDIS_DB=2
+REDIS_TLS=true
+REDIS_POOL_SIZE=20
+REDIS_DIAL_TIMEOUT=10s
+REDIS_READ_TIMEOUT=2s
+REDIS_WRITE_TIMEOUT=4s
+REDIS_MASTER_NAME=mymaster
+REDIS_SENTINEL_ADDRESSES=sentinel1:26379,sentinel2:26379
+REDIS_CLUSTER_ADDRESSES=node1:6379,node2:6379
+
+OTEL_ENABLED=true
+OTEL_SERVICE_NAME=service.otel
+OTEL_RESOURCE_ATTRIBUTES=service.namespace=shop
+OTEL_TRACES_SAMPLER=parentbased_traceidratio
+OTEL_TRACES_SAMPLER_ARG=0.5
+OTEL_EXPORTER_OTLP_ENDPOINT=http://otel.domain:4317
+OTEL_EXPORTER_OTLP_PROTOCOL=grpc
+OTEL_EXPORTER_OTLP_HEADERS=authorization=Bearer%20otel.token
+OTEL_EXPORTER_OTLP_TRACES_ENDPOINT=http://tempo.domain:4318/v1/traces
+OTEL_EXPORTER_OTLP_TRACES_PROTOCOL=http/protobuf
+OTEL_METRICS_EXPORTER=none
+
+LOG_LEVEL=debug
+LOG_FORMAT=json
+LOG_OUTPUT=stderr
+LOG_SOURCE=true
+LOG_LEVELS=main=warn
+
+SERVICES_PAYMENTS_ENABLED=true
+SERVICES_PAYMENTS_HOST=https://payments.domain
+SERVICES_PAYMENTS_TOKEN=payments.token
+SERVICES_PAYMENTS_RETRIES=2
+
+KAFKA_ENABLED=true
+KAFKA_BROKERS=kafka1.domain:9092,kafka2.domain:9092
+KAFKA_CLIENT_ID=service
+KAFKA_CONSUMER_GROUP=service.group
+KAFKA_SASL_MECHANISM=SCRAM-SHA-512
+KAFKA_SASL_USERNAME=kafka.user
+KAFKA_SASL_PASSWORD=kafka.password
+KAFKA_TLS=true
+
+NATS_ENABLED=true
+NATS_URLS=nats://nats1.domain:4222,nats://nats2.domain:4222
+NATS_CREDENTIALS_FILE=/etc/nats/user.creds
+
+RABBITMQ_ENABLED=true
+RABBITMQ_HOST=rabbitmq.domain
+RABBITMQ_USER=rabbitmq.user
+RABBITMQ_PASSWORD=rabbitmq.password
+RABBITMQ_VHOST=events
 
 TRACER_ENABLED=true
 TRACER_HOST=https://tracer.domain
@@ -77,26 +194,26 @@ port=9399
 
 func TestLoad(t *testing.T) {
 	const (
-		environment     = "dev"
-		service         = "safesystem"
-		serverHost      = "localhost"
-		serverPort      = 8080
-		database        = "database"
-		password        = "password"
-		username        = "username"
-		auditHost       = "audit.domain"
-		auditToken      = "audit.token"
-		lokiHost        = "loki.domain"
-		lokiToken       = "loki.token"
-		prometheusHost  = "prometheus.host"
-		prometheusToken = "prometheus.token"
-		tempoHost       = "tempo.domain"
-		tempoToken      = "tempo.token"
-		jaegerHost      = "jaeger.domain"
-		jaegerToken     = "jaeger.token"
-		redisHost       = "redis.domain"
-		redisToken      = "redis.token"
-		envSettings     = "setting1=value1,setting2=value2,setting3=value3"
+		environment        = "dev"
+		service            = "safesystem"
+		serverHost         = "localhost"
+		serverPort         = 8080
+		database           = "database"
+		password           = "password"
+		username           = "username"
+		auditHost          = "audit.domain"
+		auditToken         = "audit.token"
+		lokiHost           = "loki.domain"
+		lokiToken          = "loki.token"
+		prometheusHost     = "prometheus.host"
+		prometheusPassword = "prometheus.password"
+		tempoHost          = "tempo.domain"
+		tempoToken         = "tempo.token"
+		jaegerHost         = "jaeger.domain"
+		jaegerToken        = "jaeger.token"
+		redisHost          = "redis.domain"
+		redisToken         = "redis.token"
+		envSettings        = "setting1=value1,setting2=value2,setting3=value3"
 	)
 	settings := map[string]string{
 		"setting1": "value1",
@@ -108,10 +225,36 @@ func TestLoad(t *testing.T) {
 			Host:           serverHost,
 			Port:           serverPort,
 			AllowedOrigins: []string{"http://localhost:8080"},
+			CORS: config.CORS{
+				AllowedMethods:   []string{"GET", "POST"},
+				AllowedHeaders:   []string{"Content-Type", "Authorization"},
+				ExposedHeaders:   []string{"X-Request-Id"},
+				AllowCredentials: true,
+				MaxAge:           config.Duration(10 * time.Minute),
+			},
+			TLSCertFile:       "/etc/ssl/server.crt",
+			TLSKeyFile:        "/etc/ssl/server.key",
+			TLSMinVersion:     "1.3",
+			ReadTimeout:       config.Duration(10 * time.Second),
+			ReadHeaderTimeout: config.Duration(2 * time.Second),
+			WriteTimeout:      config.Duration(20 * time.Second),
+			IdleTimeout:       config.Duration(2 * time.Minute),
+			ShutdownTimeout:   config.Duration(45 * time.Second),
+			MaxHeaderBytes:    4096,
 		},
 		Token: config.Token{
-			MaxAge: 100,
-			Secret: "token",
+			MaxAge:         config.Duration(100 * time.Second),
+			Secret:         "token",
+			Algorithm:      "RS256",
+			Issuer:         "https://auth.domain",
+			Audience:       []string{"orders", "payments"},
+			RefreshMaxAge:  config.Duration(72 * time.Hour),
+			KeyID:          "2024-06",
+			PrivateKeyFile: "/etc/keys/token.pem",
+			PublicKeyFile:  "/etc/keys/token.pub",
+			VerificationKeys: []config.TokenKey{
+				{KeyID: "2024-01", PublicKeyFile: "/etc/keys/2024-01.pub"},
+			},
 		},
 		MongoDb: config.Database{
 			Host:           serverHost,
@@ -120,52 +263,198 @@ func TestLoad(t *testing.T) {
 			Password:       password,
 			Db:             database,
 			MigrationsPath: config.DefaultMigrationsMongo,
+			ReplicaSet:     "rs0",
+			Options:        "retryWrites=true",
 		},
 		MySql: config.Database{
-			Host:           serverHost,
-			Port:           serverPort,
-			User:           username,
-			Password:       password,
-			Db:             database,
-			MigrationsPath: config.DefaultMigrationsMysql,
+			Host:            serverHost,
+			Port:            serverPort,
+			User:            username,
+			Password:        password,
+			Db:              database,
+			Socket:          "/var/run/mysqld/mysqld.sock",
+			MigrationsPath:  config.DefaultMigrationsMysql,
+			MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: config.Duration(30 * time.Minute),
+			ConnMaxIdleTime: config.Duration(5 * time.Minute),
 		},
 		Postgres: config.Database{
-			Host:           serverHost,
-			Port:           serverPort,
-			User:           username,
-			Password:       password,
-			Db:             database,
-			MigrationsPath: config.DefaultMigrationsPostgres,
+			Host:            serverHost,
+			Port:            serverPort,
+			User:            username,
+			Password:        password,
+			Db:              database,
+			MigrationsPath:  config.DefaultMigrationsPostgres,
+			MaxOpenConns:    50,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: config.Duration(time.Hour),
+			ConnMaxIdleTime: config.Duration(10 * time.Minute),
+			Replicas: []config.Replica{
+				{Host: "replica1.domain"},
+				{Host: "replica2.domain", Port: 5433, User: "reader", Password: "reader.password"},
+			},
+		},
+		SQLite: config.Database{
+			Path:           "/var/lib/app/app.db",
+			Pragmas:        []string{"journal_mode=WAL", "busy_timeout=5000"},
+			MigrationsPath: config.DefaultMigrationsSQLite,
+		},
+		SQLServer: config.Database{
+			Host:            "sqlserver.domain",
+			Port:            config.DefaultSQLServerPort,
+			User:            username,
+			Password:        password,
+			Db:              database,
+			MigrationsPath:  config.DefaultMigrationsSQLServer,
+			MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: config.Duration(30 * time.Minute),
+			ConnMaxIdleTime: config.Duration(5 * time.Minute),
+		},
+		ClickHouse: config.Database{
+			Host:            "clickhouse.domain",
+			Port:            config.DefaultClickHousePort,
+			Db:              "analytics",
+			MigrationsPath:  config.DefaultMigrationsClickHouse,
+			MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: config.Duration(30 * time.Minute),
+			ConnMaxIdleTime: config.Duration(5 * time.Minute),
+		},
+		Databases: config.Databases{
+			"analytics": {
+				Driver:          config.DriverPostgres,
+				Host:            "analytics.domain",
+				Port:            5433,
+				User:            username,
+				Password:        password,
+				Db:              "analytics",
+				MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+				MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+				ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+				ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
+			},
 		},
 		Audit: config.ExternalService{
-			Enabled: true,
-			Host:    auditHost,
-			Token:   auditToken,
+			Enabled:   true,
+			Host:      auditHost,
+			Token:     auditToken,
+			Timeout:   config.Duration(5 * time.Second),
+			Retries:   3,
+			Headers:   config.Attributes{"X-Source": "orders"},
+			TLSCAFile: "ca.crt",
 		},
-		Loki: config.ExternalService{
-			Enabled: true,
-			Host:    lokiHost,
-			Token:   lokiToken,
+		Loki: config.Loki{
+			Enabled:   true,
+			Host:      lokiHost,
+			Token:     lokiToken,
+			TenantID:  "shop",
+			Labels:    config.Attributes{"team": "payments"},
+			BatchSize: 524288,
+			BatchWait: config.Duration(2 * time.Second),
+			Timeout:   config.Duration(10 * time.Second),
 		},
-		Prometheus: config.ExternalService{
-			Enabled: true,
-			Host:    prometheusHost,
-			Token:   prometheusToken,
+		Prometheus: config.Prometheus{
+			Enabled:        true,
+			Host:           prometheusHost,
+			Port:           9100,
+			Path:           "/internal/metrics",
+			Namespace:      "shop",
+			Subsystem:      "orders",
+			BasicAuth:      config.BasicAuth{Username: "prometheus.user", Password: prometheusPassword},
+			Token:          "prometheus.token",
+			PushgatewayURL: "http://pushgateway.domain:9091",
 		},
 		Tempo: config.ExternalService{
 			Enabled: true,
 			Host:    tempoHost,
 			Token:   tempoToken,
+			Timeout: config.Duration(10 * time.Second),
 		},
 		Jaeger: config.ExternalService{
 			Enabled: true,
 			Host:    jaegerHost,
 			Token:   jaegerToken,
+			Timeout: config.Duration(10 * time.Second),
 		},
-		Redis: config.ExternalService{
-			Enabled: true,
-			Host:    redisHost,
-			Token:   redisToken,
+		Redis: config.Redis{
+			Enabled:           true,
+			Host:              redisHost,
+			Token:             redisToken,
+			Username:          "redis.user",
+			Password:          "redis.password",
+			DB:                2,
+			TLS:               true,
+			PoolSize:          20,
+			DialTimeout:       config.Duration(10 * time.Second),
+			ReadTimeout:       config.Duration(2 * time.Second),
+			WriteTimeout:      config.Duration(4 * time.Second),
+			MasterName:        "mymaster",
+			SentinelAddresses: []string{"sentinel1:26379", "sentinel2:26379"},
+			ClusterAddresses:  []string{"node1:6379", "node2:6379"},
+		},
+		OTel: config.OTel{
+			Enabled:            true,
+			ServiceName:        "service.otel",
+			ResourceAttributes: config.Attributes{"service.namespace": "shop"},
+			SampleRatio:        0.5,
+			MetricsInterval:    config.Duration(time.Minute),
+			Endpoint:           "http://otel.domain:4317",
+			Protocol:           config.OTelProtocolGRPC,
+			Headers:            config.Attributes{"authorization": "Bearer otel.token"},
+			Timeout:            config.Duration(10 * time.Second),
+			Traces: config.OTelExporter{
+				Exporter: config.OTelExporterOTLP,
+				Endpoint: "http://tempo.domain:4318/v1/traces",
+				Protocol: config.OTelProtocolHTTPProtobuf,
+			},
+			Metrics: config.OTelExporter{Exporter: config.OTelExporterNone},
+			Logs:    config.OTelExporter{Exporter: config.OTelExporterOTLP},
+		},
+		Log: config.Logging{
+			Level:  "debug",
+			Format: config.LogFormatJSON,
+			Output: config.LogOutputStderr,
+			Source: true,
+			Levels: config.Attributes{"main": "warn"},
+		},
+		Services: config.Services{
+			"payments": {
+				Enabled: true,
+				Host:    "https://payments.domain",
+				Token:   "payments.token",
+				Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
+				Retries: 2,
+			},
+		},
+		Kafka: config.Kafka{
+			Enabled:       true,
+			Brokers:       []string{"kafka1.domain:9092", "kafka2.domain:9092"},
+			ClientID:      "service",
+			ConsumerGroup: "service.group",
+			DialTimeout:   config.Duration(10 * time.Second),
+			SASL: config.SASL{
+				Mechanism: config.SASLScramSHA512,
+				Username:  "kafka.user",
+				Password:  "kafka.password",
+			},
+			TLS: true,
+		},
+		NATS: config.NATS{
+			Enabled:         true,
+			URLs:            []string{"nats://nats1.domain:4222", "nats://nats2.domain:4222"},
+			CredentialsFile: "/etc/nats/user.creds",
+			ConnectTimeout:  config.Duration(2 * time.Second),
+		},
+		RabbitMQ: config.RabbitMQ{
+			Enabled:   true,
+			Host:      "rabbitmq.domain",
+			Port:      config.DefaultRabbitMQPort,
+			User:      "rabbitmq.user",
+			Password:  "rabbitmq.password",
+			VHost:     "events",
+			Heartbeat: config.Duration(10 * time.Second),
 		},
 		Environment: environment,
 		Service:     service,
@@ -183,6 +472,22 @@ func TestLoad(t *testing.T) {
 		require.NoError(t, err)
 		err = os.Setenv("TOKEN_SECRET", "token")
 		require.NoError(t, err)
+		err = os.Setenv("TOKEN_ALGORITHM", "RS256")
+		require.NoError(t, err)
+		err = os.Setenv("TOKEN_ISSUER", "https://auth.domain")
+		require.NoError(t, err)
+		err = os.Setenv("TOKEN_AUDIENCE", "orders,payments")
+		require.NoError(t, err)
+		err = os.Setenv("TOKEN_REFRESH_MAX_AGE", "72h")
+		require.NoError(t, err)
+		err = os.Setenv("TOKEN_KEY_ID", "2024-06")
+		require.NoError(t, err)
+		err = os.Setenv("TOKEN_PRIVATE_KEY_FILE", "/etc/keys/token.pem")
+		require.NoError(t, err)
+		err = os.Setenv("TOKEN_PUBLIC_KEY_FILE", "/etc/keys/token.pub")
+		require.NoError(t, err)
+		err = os.Setenv("TOKEN_VERIFICATION_KEY_FILES", "2024-01=/etc/keys/2024-01.pub")
+		require.NoError(t, err)
 		err = os.Setenv("MONGODB_HOST", serverHost)
 		require.NoError(t, err)
 		err = os.Setenv("MONGODB_PORT", "8080")
@@ -219,6 +524,14 @@ func TestLoad(t *testing.T) {
 		require.NoError(t, err)
 		err = os.Setenv("AUDIT_TOKEN", auditToken)
 		require.NoError(t, err)
+		err = os.Setenv("AUDIT_TIMEOUT", "5s")
+		require.NoError(t, err)
+		err = os.Setenv("AUDIT_RETRIES", "3")
+		require.NoError(t, err)
+		err = os.Setenv("AUDIT_HEADERS", "X-Source=orders")
+		require.NoError(t, err)
+		err = os.Setenv("AUDIT_TLS_CA_FILE", "ca.crt")
+		require.NoError(t, err)
 		err = os.Setenv("LOKI_ENABLED", "TRUE")
 		require.NoError(t, err)
 		err = os.Setenv("LOKI_HOST", lokiHost)
@@ -229,7 +542,19 @@ func TestLoad(t *testing.T) {
 		require.NoError(t, err)
 		err = os.Setenv("PROMETHEUS_HOST", prometheusHost)
 		require.NoError(t, err)
-		err = os.Setenv("PROMETHEUS_TOKEN", prometheusToken)
+		err = os.Setenv("PROMETHEUS_PORT", "9100")
+		require.NoError(t, err)
+		err = os.Setenv("PROMETHEUS_PATH", "/internal/metrics")
+		require.NoError(t, err)
+		err = os.Setenv("PROMETHEUS_NAMESPACE", "shop")
+		require.NoError(t, err)
+		err = os.Setenv("PROMETHEUS_SUBSYSTEM", "orders")
+		require.NoError(t, err)
+		err = os.Setenv("PROMETHEUS_BASIC_AUTH_USERNAME", "prometheus.user")
+		require.NoError(t, err)
+		err = os.Setenv("PROMETHEUS_BASIC_AUTH_PASSWORD", prometheusPassword)
+		require.NoError(t, err)
+		err = os.Setenv("PROMETHEUS_PUSHGATEWAY_URL", "http://pushgateway.domain:9091")
 		require.NoError(t, err)
 		err = os.Setenv("TEMPO_ENABLED", "TRUE")
 		require.NoError(t, err)
@@ -260,16 +585,41 @@ func TestLoad(t *testing.T) {
 				"SERVER_HOST",
 				"SERVER_PORT",
 				"SERVER_ALLOWED_ORIGINS",
+				"SERVER_CORS_ALLOWED_METHODS",
+				"SERVER_CORS_ALLOWED_HEADERS",
+				"SERVER_CORS_EXPOSED_HEADERS",
+				"SERVER_CORS_ALLOW_CREDENTIALS",
+				"SERVER_CORS_MAX_AGE",
+				"SERVER_TLS_CERT_FILE",
+				"SERVER_TLS_KEY_FILE",
+				"SERVER_TLS_MIN_VERSION",
+				"SERVER_READ_TIMEOUT",
+				"SERVER_READ_HEADER_TIMEOUT",
+				"SERVER_WRITE_TIMEOUT",
+				"SERVER_IDLE_TIMEOUT",
+				"SERVER_SHUTDOWN_TIMEOUT",
+				"SERVER_MAX_HEADER_BYTES",
 				"TOKEN_MAX_AGE",
 				"TOKEN_SECRET",
+				"TOKEN_ALGORITHM",
+				"TOKEN_ISSUER",
+				"TOKEN_AUDIENCE",
+				"TOKEN_REFRESH_MAX_AGE",
+				"TOKEN_KEY_ID",
+				"TOKEN_PRIVATE_KEY_FILE",
+				"TOKEN_PUBLIC_KEY_FILE",
+				"TOKEN_VERIFICATION_KEY_FILES",
 				"MONGODB_HOST",
 				"MONGODB_PORT",
 				"MONGODB_USER",
+				"MONGODB_REPLICA_SET",
+				"MONGODB_OPTIONS",
 				"MONGODB_PASSWORD",
 				"MONGODB_DATABASE",
 				"MYSQL_HOST",
 				"MYSQL_PORT",
 				"MYSQL_USER",
+				"MYSQL_SOCKET",
 				"MYSQL_PASSWORD",
 				"MYSQL_DATABASE",
 				"POSTGRES_HOST",
@@ -277,15 +627,49 @@ func TestLoad(t *testing.T) {
 				"POSTGRES_USER",
 				"POSTGRES_PASSWORD",
 				"POSTGRES_DATABASE",
+				"POSTGRES_MAX_OPEN_CONNS",
+				"POSTGRES_MAX_IDLE_CONNS",
+				"POSTGRES_CONN_MAX_LIFETIME",
+				"POSTGRES_CONN_MAX_IDLE_TIME",
+				"POSTGRES_REPLICAS",
+				"SQLITE_PATH",
+				"SQLITE_PRAGMAS",
+				"SQLSERVER_HOST",
+				"SQLSERVER_USER",
+				"SQLSERVER_PASSWORD",
+				"SQLSERVER_DATABASE",
+				"CLICKHOUSE_HOST",
+				"CLICKHOUSE_DATABASE",
+				"DATABASES_ANALYTICS_DRIVER",
+				"DATABASES_ANALYTICS_HOST",
+				"DATABASES_ANALYTICS_PORT",
+				"DATABASES_ANALYTICS_USER",
+				"DATABASES_ANALYTICS_PASSWORD",
+				"DATABASES_ANALYTICS_DATABASE",
 				"AUDIT_ENABLED",
 				"AUDIT_HOST",
 				"AUDIT_TOKEN",
+				"AUDIT_TIMEOUT",
+				"AUDIT_RETRIES",
+				"AUDIT_HEADERS",
+				"AUDIT_TLS_CA_FILE",
 				"LOKI_ENABLED",
 				"LOKI_HOST",
 				"LOKI_TOKEN",
+				"LOKI_TENANT_ID",
+				"LOKI_LABELS",
+				"LOKI_BATCH_SIZE",
+				"LOKI_BATCH_WAIT",
 				"PROMETHEUS_ENABLED",
 				"PROMETHEUS_HOST",
+				"PROMETHEUS_PORT",
+				"PROMETHEUS_PATH",
+				"PROMETHEUS_NAMESPACE",
+				"PROMETHEUS_SUBSYSTEM",
+				"PROMETHEUS_BASIC_AUTH_USERNAME",
+				"PROMETHEUS_BASIC_AUTH_PASSWORD",
 				"PROMETHEUS_TOKEN",
+				"PROMETHEUS_PUSHGATEWAY_URL",
 				"TEMPO_ENABLED",
 				"TEMPO_HOST",
 				"TEMPO_TOKEN",
@@ -295,6 +679,53 @@ func TestLoad(t *testing.T) {
 				"REDIS_ENABLED",
 				"REDIS_HOST",
 				"REDIS_TOKEN",
+				"REDIS_USERNAME",
+				"REDIS_PASSWORD",
+				"REDIS_DB",
+				"REDIS_TLS",
+				"REDIS_POOL_SIZE",
+				"REDIS_DIAL_TIMEOUT",
+				"REDIS_READ_TIMEOUT",
+				"REDIS_WRITE_TIMEOUT",
+				"REDIS_MASTER_NAME",
+				"REDIS_SENTINEL_ADDRESSES",
+				"REDIS_CLUSTER_ADDRESSES",
+				"OTEL_ENABLED",
+				"OTEL_SERVICE_NAME",
+				"OTEL_RESOURCE_ATTRIBUTES",
+				"OTEL_TRACES_SAMPLER",
+				"OTEL_TRACES_SAMPLER_ARG",
+				"OTEL_EXPORTER_OTLP_ENDPOINT",
+				"OTEL_EXPORTER_OTLP_PROTOCOL",
+				"OTEL_EXPORTER_OTLP_HEADERS",
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL",
+				"OTEL_METRICS_EXPORTER",
+				"LOG_LEVEL",
+				"LOG_FORMAT",
+				"LOG_OUTPUT",
+				"LOG_SOURCE",
+				"LOG_LEVELS",
+				"SERVICES_PAYMENTS_ENABLED",
+				"SERVICES_PAYMENTS_HOST",
+				"SERVICES_PAYMENTS_TOKEN",
+				"SERVICES_PAYMENTS_RETRIES",
+				"KAFKA_ENABLED",
+				"KAFKA_BROKERS",
+				"KAFKA_CLIENT_ID",
+				"KAFKA_CONSUMER_GROUP",
+				"KAFKA_SASL_MECHANISM",
+				"KAFKA_SASL_USERNAME",
+				"KAFKA_SASL_PASSWORD",
+				"KAFKA_TLS",
+				"NATS_ENABLED",
+				"NATS_URLS",
+				"NATS_CREDENTIALS_FILE",
+				"RABBITMQ_ENABLED",
+				"RABBITMQ_HOST",
+				"RABBITMQ_USER",
+				"RABBITMQ_PASSWORD",
+				"RABBITMQ_VHOST",
 				"SERVICE",
 				"ENVIRONMENT",
 				"SETTINGS",
@@ -312,32 +743,115 @@ func TestLoad(t *testing.T) {
 
 	t.Run("without optional fields", func(t *testing.T) {
 		expectedConfig := config.Config{
+			Server: config.Server{
+				ReadTimeout:       config.Duration(config.DefaultServerReadTimeout * time.Second),
+				ReadHeaderTimeout: config.Duration(config.DefaultServerReadHeaderTimeout * time.Second),
+				WriteTimeout:      config.Duration(config.DefaultServerWriteTimeout * time.Second),
+				IdleTimeout:       config.Duration(config.DefaultServerIdleTimeout * time.Second),
+				ShutdownTimeout:   config.Duration(config.DefaultServerShutdownTimeout * time.Second),
+				MaxHeaderBytes:    config.DefaultServerMaxHeaderBytes,
+				TLSMinVersion:     config.DefaultServerTLSMinVersion,
+			},
 			MySql: config.Database{
-				Port:           config.DefaultMySQLPort,
-				MigrationsPath: config.DefaultMigrationsMysql,
+				Port:            config.DefaultMySQLPort,
+				MigrationsPath:  config.DefaultMigrationsMysql,
+				MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+				MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+				ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+				ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
 			},
 			MongoDb: config.Database{
 				Port:           config.DefaultMongoPort,
 				MigrationsPath: config.DefaultMigrationsMongo,
 			},
 			Postgres: config.Database{
-				Port:           config.DefaultPostgresPort,
-				MigrationsPath: config.DefaultMigrationsPostgres,
+				Port:            config.DefaultPostgresPort,
+				MigrationsPath:  config.DefaultMigrationsPostgres,
+				MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+				MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+				ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+				ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
+			},
+			SQLite: config.Database{
+				MigrationsPath: config.DefaultMigrationsSQLite,
+			},
+			SQLServer: config.Database{
+				Port:            config.DefaultSQLServerPort,
+				MigrationsPath:  config.DefaultMigrationsSQLServer,
+				MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+				MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+				ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+				ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
+			},
+			ClickHouse: config.Database{
+				Port:            config.DefaultClickHousePort,
+				MigrationsPath:  config.DefaultMigrationsClickHouse,
+				MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+				MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+				ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+				ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
+			},
+			Token: config.Token{
+				MaxAge:        config.Duration(config.DefaultSessionMaxAge * time.Second),
+				Algorithm:     config.DefaultTokenAlgorithm,
+				RefreshMaxAge: config.Duration(config.DefaultRefreshMaxAge * time.Second),
+			},
+			Audit: config.ExternalService{
+				Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
 			},
-			Loki: config.ExternalService{
-				Host: config.DefaultLokiHost,
+			Loki: config.Loki{
+				Host:      config.DefaultLokiHost,
+				BatchSize: config.DefaultLokiBatchSize,
+				BatchWait: config.Duration(config.DefaultLokiBatchWait * time.Second),
+				Timeout:   config.Duration(config.DefaultLokiTimeout * time.Second),
 			},
 			Tempo: config.ExternalService{
-				Host: config.DefaultTempoHost,
+				Host:    config.DefaultTempoHost,
+				Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
 			},
 			Jaeger: config.ExternalService{
-				Host: config.DefaultJaegerHost,
+				Host:    config.DefaultJaegerHost,
+				Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
 			},
-			Redis: config.ExternalService{
-				Host: config.DefaultRedisHost,
+			Prometheus: config.Prometheus{
+				Port: config.DefaultPrometheusPort,
+				Path: config.DefaultPrometheusPath,
 			},
-			Token: config.Token{
-				MaxAge: config.DefaultSessionMaxAge,
+			Redis: config.Redis{
+				Host:         config.DefaultRedisHost,
+				DialTimeout:  config.Duration(config.DefaultRedisDialTimeout * time.Second),
+				ReadTimeout:  config.Duration(config.DefaultRedisReadTimeout * time.Second),
+				WriteTimeout: config.Duration(config.DefaultRedisWriteTimeout * time.Second),
+			},
+			OTel: config.OTel{
+				SampleRatio:     config.DefaultOTelSampleRatio,
+				MetricsInterval: config.Duration(config.DefaultOTelMetricsInterval * time.Second),
+				Endpoint:        config.DefaultOTelEndpoint,
+				Protocol:        config.DefaultOTelProtocol,
+				Timeout:         config.Duration(config.DefaultOTelTimeout * time.Second),
+				Traces:          config.OTelExporter{Exporter: config.DefaultOTelExporter},
+				Metrics:         config.OTelExporter{Exporter: config.DefaultOTelExporter},
+				Logs:            config.OTelExporter{Exporter: config.DefaultOTelExporter},
+			},
+			Log: config.Logging{
+				Level:  config.DefaultLogLevel,
+				Format: config.DefaultLogFormat,
+				Output: config.DefaultLogOutput,
+			},
+			Kafka: config.Kafka{
+				DialTimeout: config.Duration(config.DefaultKafkaDialTimeout * time.Second),
+				SASL: config.SASL{
+					Mechanism: config.DefaultKafkaSASLMechanism,
+				},
+			},
+			NATS: config.NATS{
+				ConnectTimeout: config.Duration(config.DefaultNATSConnectTimeout * time.Second),
+			},
+			RabbitMQ: config.RabbitMQ{
+				Host:      config.DefaultRabbitMQHost,
+				Port:      config.DefaultRabbitMQPort,
+				VHost:     config.DefaultRabbitMQVHost,
+				Heartbeat: config.Duration(config.DefaultRabbitMQHeartbeat * time.Second),
 			},
 			Settings: map[string]string{},
 		}
@@ -346,16 +860,41 @@ func TestLoad(t *testing.T) {
 				"SERVER_HOST",
 				"SERVER_PORT",
 				"SERVER_ALLOWED_ORIGINS",
+				"SERVER_CORS_ALLOWED_METHODS",
+				"SERVER_CORS_ALLOWED_HEADERS",
+				"SERVER_CORS_EXPOSED_HEADERS",
+				"SERVER_CORS_ALLOW_CREDENTIALS",
+				"SERVER_CORS_MAX_AGE",
+				"SERVER_TLS_CERT_FILE",
+				"SERVER_TLS_KEY_FILE",
+				"SERVER_TLS_MIN_VERSION",
+				"SERVER_READ_TIMEOUT",
+				"SERVER_READ_HEADER_TIMEOUT",
+				"SERVER_WRITE_TIMEOUT",
+				"SERVER_IDLE_TIMEOUT",
+				"SERVER_SHUTDOWN_TIMEOUT",
+				"SERVER_MAX_HEADER_BYTES",
 				"TOKEN_MAX_AGE",
 				"TOKEN_SECRET",
+				"TOKEN_ALGORITHM",
+				"TOKEN_ISSUER",
+				"TOKEN_AUDIENCE",
+				"TOKEN_REFRESH_MAX_AGE",
+				"TOKEN_KEY_ID",
+				"TOKEN_PRIVATE_KEY_FILE",
+				"TOKEN_PUBLIC_KEY_FILE",
+				"TOKEN_VERIFICATION_KEY_FILES",
 				"MONGODB_HOST",
 				"MONGODB_PORT",
 				"MONGODB_USER",
+				"MONGODB_REPLICA_SET",
+				"MONGODB_OPTIONS",
 				"MONGODB_PASSWORD",
 				"MONGODB_DATABASE",
 				"MYSQL_HOST",
 				"MYSQL_PORT",
 				"MYSQL_USER",
+				"MYSQL_SOCKET",
 				"MYSQL_PASSWORD",
 				"MYSQL_DATABASE",
 				"POSTGRES_HOST",
@@ -363,15 +902,49 @@ func TestLoad(t *testing.T) {
 				"POSTGRES_USER",
 				"POSTGRES_PASSWORD",
 				"POSTGRES_DATABASE",
+				"POSTGRES_MAX_OPEN_CONNS",
+				"POSTGRES_MAX_IDLE_CONNS",
+				"POSTGRES_CONN_MAX_LIFETIME",
+				"POSTGRES_CONN_MAX_IDLE_TIME",
+				"POSTGRES_REPLICAS",
+				"SQLITE_PATH",
+				"SQLITE_PRAGMAS",
+				"SQLSERVER_HOST",
+				"SQLSERVER_USER",
+				"SQLSERVER_PASSWORD",
+				"SQLSERVER_DATABASE",
+				"CLICKHOUSE_HOST",
+				"CLICKHOUSE_DATABASE",
+				"DATABASES_ANALYTICS_DRIVER",
+				"DATABASES_ANALYTICS_HOST",
+				"DATABASES_ANALYTICS_PORT",
+				"DATABASES_ANALYTICS_USER",
+				"DATABASES_ANALYTICS_PASSWORD",
+				"DATABASES_ANALYTICS_DATABASE",
 				"AUDIT_ENABLED",
 				"AUDIT_HOST",
 				"AUDIT_TOKEN",
+				"AUDIT_TIMEOUT",
+				"AUDIT_RETRIES",
+				"AUDIT_HEADERS",
+				"AUDIT_TLS_CA_FILE",
 				"LOKI_ENABLED",
 				"LOKI_HOST",
 				"LOKI_TOKEN",
+				"LOKI_TENANT_ID",
+				"LOKI_LABELS",
+				"LOKI_BATCH_SIZE",
+				"LOKI_BATCH_WAIT",
 				"PROMETHEUS_ENABLED",
 				"PROMETHEUS_HOST",
+				"PROMETHEUS_PORT",
+				"PROMETHEUS_PATH",
+				"PROMETHEUS_NAMESPACE",
+				"PROMETHEUS_SUBSYSTEM",
+				"PROMETHEUS_BASIC_AUTH_USERNAME",
+				"PROMETHEUS_BASIC_AUTH_PASSWORD",
 				"PROMETHEUS_TOKEN",
+				"PROMETHEUS_PUSHGATEWAY_URL",
 				"TEMPO_ENABLED",
 				"TEMPO_HOST",
 				"TEMPO_TOKEN",
@@ -381,6 +954,53 @@ func TestLoad(t *testing.T) {
 				"REDIS_ENABLED",
 				"REDIS_HOST",
 				"REDIS_TOKEN",
+				"REDIS_USERNAME",
+				"REDIS_PASSWORD",
+				"REDIS_DB",
+				"REDIS_TLS",
+				"REDIS_POOL_SIZE",
+				"REDIS_DIAL_TIMEOUT",
+				"REDIS_READ_TIMEOUT",
+				"REDIS_WRITE_TIMEOUT",
+				"REDIS_MASTER_NAME",
+				"REDIS_SENTINEL_ADDRESSES",
+				"REDIS_CLUSTER_ADDRESSES",
+				"OTEL_ENABLED",
+				"OTEL_SERVICE_NAME",
+				"OTEL_RESOURCE_ATTRIBUTES",
+				"OTEL_TRACES_SAMPLER",
+				"OTEL_TRACES_SAMPLER_ARG",
+				"OTEL_EXPORTER_OTLP_ENDPOINT",
+				"OTEL_EXPORTER_OTLP_PROTOCOL",
+				"OTEL_EXPORTER_OTLP_HEADERS",
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL",
+				"OTEL_METRICS_EXPORTER",
+				"LOG_LEVEL",
+				"LOG_FORMAT",
+				"LOG_OUTPUT",
+				"LOG_SOURCE",
+				"LOG_LEVELS",
+				"SERVICES_PAYMENTS_ENABLED",
+				"SERVICES_PAYMENTS_HOST",
+				"SERVICES_PAYMENTS_TOKEN",
+				"SERVICES_PAYMENTS_RETRIES",
+				"KAFKA_ENABLED",
+				"KAFKA_BROKERS",
+				"KAFKA_CLIENT_ID",
+				"KAFKA_CONSUMER_GROUP",
+				"KAFKA_SASL_MECHANISM",
+				"KAFKA_SASL_USERNAME",
+				"KAFKA_SASL_PASSWORD",
+				"KAFKA_TLS",
+				"NATS_ENABLED",
+				"NATS_URLS",
+				"NATS_CREDENTIALS_FILE",
+				"RABBITMQ_ENABLED",
+				"RABBITMQ_HOST",
+				"RABBITMQ_USER",
+				"RABBITMQ_PASSWORD",
+				"RABBITMQ_VHOST",
 				"SERVICE",
 				"ENVIRONMENT",
 				"SETTINGS",
@@ -428,6 +1048,33 @@ func TestLoad(t *testing.T) {
 
 			closeFile(t, tempFile)
 		})
+
+		t.Run("invalid file value with file source", func(t *testing.T) {
+			tempFile := createTempFile(t, "SERVER_PORT=error\n")
+			defer unsetEnvVars(t, "SERVER_PORT")
+
+			_, err := Load(tempFile.Name())
+
+			var cfgErr *config.Error
+			require.ErrorAs(t, err, &cfgErr)
+			assert.Equal(t, tempFile.Name(), cfgErr.Source)
+			assert.Equal(t, "SERVER_PORT", cfgErr.Key)
+			assert.ErrorContains(t, err, `invalid int value: strconv.Atoi: parsing "error": invalid syntax`)
+
+			closeFile(t, tempFile)
+		})
+
+		t.Run("invalid file content with error location", func(t *testing.T) {
+			tempFile := createTempFile(t, configContentInvalid)
+
+			_, err := Load(tempFile.Name())
+
+			var cfgErr *config.Error
+			require.ErrorAs(t, err, &cfgErr)
+			assert.Equal(t, tempFile.Name(), cfgErr.Source)
+
+			closeFile(t, tempFile)
+		})
 	})
 }
 
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Duration is a time.Duration read from duration strings, as "24h" or "90m", or from integer seconds.
+// Integer seconds keep configs written before Duration was introduced valid.
+type Duration time.Duration
+
+// ParseDuration parses a duration string, as "1h30m", or a number of seconds, as "5400".
+func ParseDuration(value string) (Duration, error) {
+	value = strings.TrimSpace(value)
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err == nil {
+		return Duration(time.Duration(seconds) * time.Second), nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q, use a number of seconds or a duration as \"24h\"", value)
+	}
+
+	return Duration(duration), nil
+}
+
+// Duration returns the duration as a time.Duration.
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// String returns the duration in its shortest form, as "24h" or "1h30m".
+func (d Duration) String() string {
+	value := time.Duration(d).String()
+	if strings.HasSuffix(value, "m0s") {
+		value = strings.TrimSuffix(value, "0s")
+	}
+	if strings.HasSuffix(value, "h0m") {
+		value = strings.TrimSuffix(value, "0m")
+	}
+	return value
+}
+
+// MarshalText encodes the duration as a duration string.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a duration string or a number of seconds. It is used by toml, xml and env values.
+func (d *Duration) UnmarshalText(text []byte) error {
+	duration, err := ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = duration
+	return nil
+}
+
+// UnmarshalJSON decodes a json string or number, as a duration string or a number of seconds.
+func (d *Duration) UnmarshalJSON(content []byte) error {
+	var value any
+	if err := json.Unmarshal(content, &value); err != nil {
+		return err
+	}
+
+	switch typedValue := value.(type) {
+	case string:
+		return d.UnmarshalText([]byte(typedValue))
+	case float64:
+		*d = Duration(time.Duration(typedValue * float64(time.Second)))
+		return nil
+	}
+	return fmt.Errorf("invalid duration %s, use a number of seconds or a duration as \"24h\"", content)
+}
+
+// UnmarshalYAML decodes a yaml string or integer, as a duration string or a number of seconds.
+// Errors are returned as yaml.TypeError, so they are reported with their line, as other yaml type errors.
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	err := value.Decode(new(string))
+	if err == nil {
+		err = d.UnmarshalText([]byte(value.Value))
+	}
+	if err != nil {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			return typeErr
+		}
+		return &yaml.TypeError{Errors: []string{fmt.Sprintf("line %d: %s", value.Line, err)}}
+	}
+
+	return nil
+}
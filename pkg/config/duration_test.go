@@ -0,0 +1,120 @@
+package config
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type durationHolder struct {
+	XMLName  xml.Name `xml:"holder"`
+	Duration Duration `toml:"duration" yaml:"duration" json:"duration" xml:"duration"`
+}
+
+func TestParseDuration(t *testing.T) {
+	t.Run("should parse duration strings", func(t *testing.T) {
+		duration, err := ParseDuration("1h30m")
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Minute, duration.Duration())
+	})
+
+	t.Run("should parse integers as seconds", func(t *testing.T) {
+		duration, err := ParseDuration(" 86400 ")
+		require.NoError(t, err)
+		assert.Equal(t, 24*time.Hour, duration.Duration())
+	})
+
+	t.Run("should return an error for invalid values", func(t *testing.T) {
+		_, err := ParseDuration("one day")
+		assert.EqualError(t, err, `invalid duration "one day", use a number of seconds or a duration as "24h"`)
+	})
+}
+
+func TestDuration_String(t *testing.T) {
+	tests := map[Duration]string{
+		Duration(24 * time.Hour):                 "24h",
+		Duration(90 * time.Minute):               "1h30m",
+		Duration(90 * time.Second):               "1m30s",
+		Duration(time.Hour + time.Second):        "1h0m1s",
+		Duration(1500 * time.Millisecond):        "1.5s",
+		Duration(0):                              "0s",
+		Duration(2*time.Hour + 30*time.Minute):   "2h30m",
+		Duration(30*time.Minute + 5*time.Second): "30m5s",
+	}
+
+	for duration, expected := range tests {
+		assert.Equal(t, expected, duration.String())
+	}
+}
+
+func TestDuration_Unmarshal(t *testing.T) {
+	expected := Duration(100 * time.Second)
+
+	t.Run("should decode toml strings and integers", func(t *testing.T) {
+		for _, content := range []string{`duration = 100`, `duration = "1m40s"`} {
+			var holder durationHolder
+			err := toml.Unmarshal([]byte(content), &holder)
+			require.NoError(t, err, content)
+			assert.Equal(t, expected, holder.Duration, content)
+		}
+	})
+
+	t.Run("should decode yaml strings and integers", func(t *testing.T) {
+		for _, content := range []string{`duration: 100`, `duration: 1m40s`} {
+			var holder durationHolder
+			err := yaml.Unmarshal([]byte(content), &holder)
+			require.NoError(t, err, content)
+			assert.Equal(t, expected, holder.Duration, content)
+		}
+	})
+
+	t.Run("should decode json strings and numbers", func(t *testing.T) {
+		for _, content := range []string{`{"duration": 100}`, `{"duration": "1m40s"}`} {
+			var holder durationHolder
+			err := json.Unmarshal([]byte(content), &holder)
+			require.NoError(t, err, content)
+			assert.Equal(t, expected, holder.Duration, content)
+		}
+	})
+
+	t.Run("should decode xml strings and integers", func(t *testing.T) {
+		for _, content := range []string{`<holder><duration>100</duration></holder>`, `<holder><duration>1m40s</duration></holder>`} {
+			var holder durationHolder
+			err := xml.Unmarshal([]byte(content), &holder)
+			require.NoError(t, err, content)
+			assert.Equal(t, expected, holder.Duration, content)
+		}
+	})
+
+	t.Run("should return yaml errors with line", func(t *testing.T) {
+		var holder durationHolder
+		err := yaml.Unmarshal([]byte("\nduration: never"), &holder)
+
+		var typeErr *yaml.TypeError
+		require.ErrorAs(t, err, &typeErr)
+		assert.Equal(t, []string{`line 2: invalid duration "never", use a number of seconds or a duration as "24h"`}, typeErr.Errors)
+	})
+
+	t.Run("should return json errors for invalid types", func(t *testing.T) {
+		var holder durationHolder
+		err := json.Unmarshal([]byte(`{"duration": true}`), &holder)
+		assert.Error(t, err)
+	})
+}
+
+func TestDuration_MarshalText(t *testing.T) {
+	content, err := json.Marshal(Duration(90 * time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, `"1h30m"`, string(content))
+}
+
+func TestToken_GetMaxAge(t *testing.T) {
+	token := Token{MaxAge: Duration(time.Hour)}
+	assert.Equal(t, time.Hour, token.GetMaxAge())
+}
@@ -2,9 +2,13 @@ package env
 
 import (
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ribeirohugo/go_config/v2/pkg/config"
 )
@@ -15,159 +19,792 @@ const (
 
 // Load loads configurations from the OS environment.
 func Load() (config.Config, error) {
-	serverPort, err := getNumber("SERVER_PORT", defaultInt)
+	return Override(config.Default())
+}
+
+// Override applies OS environment variables on top of a given configuration.
+// Unset or empty variables keep the value already held by cfg.
+func Override(cfg config.Config) (config.Config, error) {
+	var err error
+
+	cfg.Server, err = loadServer(cfg.Server)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	cfg.Token, err = loadToken(cfg.Token)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	cfg, err = loadDatabaseURL(cfg)
 	if err != nil {
 		return config.Config{}, err
 	}
-	rawServerAllowedOrigins := os.Getenv("SERVER_ALLOWED_ORIGINS")
-	var serverAllowedOrigins []string
-	if rawServerAllowedOrigins != "" {
-		serverAllowedOrigins = strings.Split(rawServerAllowedOrigins, ",")
+	cfg.MongoDb, err = loadMongodb(cfg.MongoDb)
+	if err != nil {
+		return config.Config{}, err
 	}
-	tokenAge, err := getNumber("TOKEN_MAX_AGE", config.DefaultSessionMaxAge)
+	cfg.MySql, err = loadDatabase("MYSQL", config.DriverMySQL, cfg.MySql)
 	if err != nil {
 		return config.Config{}, err
 	}
-	auditEnabled, err := getBool("AUDIT_ENABLED")
+	cfg.Postgres, err = loadDatabase("POSTGRES", config.DriverPostgres, cfg.Postgres)
 	if err != nil {
 		return config.Config{}, err
 	}
-	lokiEnabled, err := getBool("LOKI_ENABLED")
+	cfg.SQLite, err = loadDatabase("SQLITE", config.DriverSQLite, cfg.SQLite)
 	if err != nil {
 		return config.Config{}, err
 	}
-	lokiHost := os.Getenv("LOKI_HOST")
-	if lokiHost == "" {
-		lokiHost = config.DefaultLokiHost
+	cfg.SQLServer, err = loadDatabase("SQLSERVER", config.DriverSQLServer, cfg.SQLServer)
+	if err != nil {
+		return config.Config{}, err
 	}
-	prometheusEnabled, err := getBool("PROMETHEUS_ENABLED")
+	cfg.ClickHouse, err = loadDatabase("CLICKHOUSE", config.DriverClickHouse, cfg.ClickHouse)
 	if err != nil {
 		return config.Config{}, err
 	}
-	tempoEnabled, err := getBool("TEMPO_ENABLED")
+	cfg.Databases, err = loadDatabases(cfg.Databases)
 	if err != nil {
 		return config.Config{}, err
 	}
-	tempoHost := os.Getenv("TEMPO_HOST")
-	if tempoHost == "" {
-		tempoHost = config.DefaultTempoHost
+
+	cfg.Audit, err = loadExternalService("AUDIT", cfg.Audit)
+	if err != nil {
+		return config.Config{}, err
 	}
-	jaegerEnabled, err := getBool("JAEGER_ENABLED")
+	cfg.Jaeger, err = loadExternalService("JAEGER", cfg.Jaeger)
 	if err != nil {
 		return config.Config{}, err
 	}
-	jaegerHost := os.Getenv("JAEGER_HOST")
-	if jaegerHost == "" {
-		jaegerHost = config.DefaultJaegerHost
+	cfg.Loki, err = loadLoki(cfg.Loki)
+	if err != nil {
+		return config.Config{}, err
 	}
-	redisEnabled, err := getBool("REDIS_ENABLED")
+	cfg.Tempo, err = loadExternalService("TEMPO", cfg.Tempo)
 	if err != nil {
 		return config.Config{}, err
 	}
-	redisHost := os.Getenv("REDIS_HOST")
-	if redisHost == "" {
-		redisHost = config.DefaultRedisHost
+	cfg.Prometheus, err = loadPrometheus(cfg.Prometheus)
+	if err != nil {
+		return config.Config{}, err
+	}
+	cfg.Redis, err = loadRedis(cfg.Redis)
+	if err != nil {
+		return config.Config{}, err
 	}
 
-	// Load Defaults
-	mongoDBMigrationsPath := os.Getenv("MONGODB_MIGRATIONS_PATH")
-	if mongoDBMigrationsPath == "" {
-		mongoDBMigrationsPath = config.DefaultMigrationsMongo
+	cfg.OTel, err = loadOTel(cfg.OTel)
+	if err != nil {
+		return config.Config{}, err
 	}
-	mongoDBPort, err := getNumber("MONGODB_PORT", config.DefaultMongoPort)
+
+	cfg.Log, err = loadLogging(cfg.Log)
 	if err != nil {
 		return config.Config{}, err
 	}
-	mySQLMigrationsPath := os.Getenv("MYSQL_MIGRATIONS_PATH")
-	if mySQLMigrationsPath == "" {
-		mySQLMigrationsPath = config.DefaultMigrationsMysql
+	cfg.Services, err = loadServices(cfg.Services)
+	if err != nil {
+		return config.Config{}, err
 	}
-	mySQLPort, err := getNumber("MYSQL_PORT", config.DefaultMySQLPort)
+
+	cfg.Kafka, err = loadKafka(cfg.Kafka)
 	if err != nil {
 		return config.Config{}, err
 	}
-	postgresMigrationsPath := os.Getenv("POSTGRES_MIGRATIONS_PATH")
-	if postgresMigrationsPath == "" {
-		postgresMigrationsPath = config.DefaultMigrationsPostgres
+	cfg.NATS, err = loadNATS(cfg.NATS)
+	if err != nil {
+		return config.Config{}, err
 	}
-	postgresPort, err := getNumber("POSTGRES_PORT", config.DefaultPostgresPort)
+	cfg.RabbitMQ, err = loadRabbitMQ(cfg.RabbitMQ)
 	if err != nil {
 		return config.Config{}, err
 	}
 
-	// Load env variables
-	cfg := config.Config{
-		Server: config.Server{
-			Host:           os.Getenv("SERVER_HOST"),
-			Port:           serverPort,
-			AllowedOrigins: serverAllowedOrigins,
-		},
-		Token: config.Token{
-			MaxAge: tokenAge,
-			Secret: os.Getenv("TOKEN_SECRET"),
-		},
-		MongoDb: config.Database{
-			Host:           os.Getenv("MONGODB_HOST"),
-			Port:           mongoDBPort,
-			User:           os.Getenv("MONGODB_USER"),
-			Password:       os.Getenv("MONGODB_PASSWORD"),
-			Db:             os.Getenv("MONGODB_DATABASE"),
-			MigrationsPath: mongoDBMigrationsPath,
-		},
-		MySql: config.Database{
-			Host:           os.Getenv("MYSQL_HOST"),
-			Port:           mySQLPort,
-			User:           os.Getenv("MYSQL_USER"),
-			Password:       os.Getenv("MYSQL_PASSWORD"),
-			Db:             os.Getenv("MYSQL_DATABASE"),
-			MigrationsPath: mySQLMigrationsPath,
-		},
-		Postgres: config.Database{
-			Host:           os.Getenv("POSTGRES_HOST"),
-			Port:           postgresPort,
-			User:           os.Getenv("POSTGRES_USER"),
-			Password:       os.Getenv("POSTGRES_PASSWORD"),
-			Db:             os.Getenv("POSTGRES_DATABASE"),
-			MigrationsPath: postgresMigrationsPath,
-		},
-		Audit: config.ExternalService{
-			Enabled: auditEnabled,
-			Host:    os.Getenv("AUDIT_HOST"),
-			Token:   os.Getenv("AUDIT_TOKEN"),
-		},
-		Loki: config.ExternalService{
-			Enabled: lokiEnabled,
-			Host:    lokiHost,
-			Token:   os.Getenv("LOKI_TOKEN"),
-		},
-		Prometheus: config.ExternalService{
-			Enabled: prometheusEnabled,
-			Host:    os.Getenv("PROMETHEUS_HOST"),
-			Token:   os.Getenv("PROMETHEUS_TOKEN"),
-		},
-		Tempo: config.ExternalService{
-			Enabled: tempoEnabled,
-			Host:    tempoHost,
-			Token:   os.Getenv("TEMPO_TOKEN"),
-		},
-		Jaeger: config.ExternalService{
-			Enabled: jaegerEnabled,
-			Host:    jaegerHost,
-			Token:   os.Getenv("JAEGER_TOKEN"),
-		},
-		Redis: config.ExternalService{
-			Enabled: redisEnabled,
-			Host:    redisHost,
-			Token:   os.Getenv("REDIS_TOKEN"),
-		},
-		Environment: os.Getenv("ENVIRONMENT"),
-		Service:     os.Getenv("SERVICE"),
-		Settings:    getStringMap("SETTINGS"),
+	cfg.Environment = getString("ENVIRONMENT", cfg.Environment)
+	cfg.Service = getString("SERVICE", cfg.Service)
+	cfg.Settings = mergeStringMap(cfg.Settings, getStringMap("SETTINGS"))
+
+	return cfg, nil
+}
+
+// loadServer overrides server values with variables prefixed by SERVER, as in SERVER_HOST.
+func loadServer(server config.Server) (config.Server, error) {
+	var err error
+
+	server.Host = getString("SERVER_HOST", server.Host)
+	server.Port, err = getNumber("SERVER_PORT", server.Port)
+	if err != nil {
+		return config.Server{}, err
+	}
+	server.AllowedOrigins = getStringSlice("SERVER_ALLOWED_ORIGINS", server.AllowedOrigins)
+	server.CORS.AllowedMethods = getStringSlice("SERVER_CORS_ALLOWED_METHODS", server.CORS.AllowedMethods)
+	server.CORS.AllowedHeaders = getStringSlice("SERVER_CORS_ALLOWED_HEADERS", server.CORS.AllowedHeaders)
+	server.CORS.ExposedHeaders = getStringSlice("SERVER_CORS_EXPOSED_HEADERS", server.CORS.ExposedHeaders)
+	server.CORS.AllowCredentials, err = getBool("SERVER_CORS_ALLOW_CREDENTIALS", server.CORS.AllowCredentials)
+	if err != nil {
+		return config.Server{}, err
+	}
+	server.CORS.MaxAge, err = getDuration("SERVER_CORS_MAX_AGE", server.CORS.MaxAge)
+	if err != nil {
+		return config.Server{}, err
+	}
+
+	server.TLSCertFile = getString("SERVER_TLS_CERT_FILE", server.TLSCertFile)
+	server.TLSKeyFile = getString("SERVER_TLS_KEY_FILE", server.TLSKeyFile)
+	server.TLSMinVersion = getString("SERVER_TLS_MIN_VERSION", server.TLSMinVersion)
+
+	server.ReadTimeout, err = getDuration("SERVER_READ_TIMEOUT", server.ReadTimeout)
+	if err != nil {
+		return config.Server{}, err
+	}
+	server.ReadHeaderTimeout, err = getDuration("SERVER_READ_HEADER_TIMEOUT", server.ReadHeaderTimeout)
+	if err != nil {
+		return config.Server{}, err
+	}
+	server.WriteTimeout, err = getDuration("SERVER_WRITE_TIMEOUT", server.WriteTimeout)
+	if err != nil {
+		return config.Server{}, err
+	}
+	server.IdleTimeout, err = getDuration("SERVER_IDLE_TIMEOUT", server.IdleTimeout)
+	if err != nil {
+		return config.Server{}, err
+	}
+	server.ShutdownTimeout, err = getDuration("SERVER_SHUTDOWN_TIMEOUT", server.ShutdownTimeout)
+	if err != nil {
+		return config.Server{}, err
+	}
+	server.MaxHeaderBytes, err = getNumber("SERVER_MAX_HEADER_BYTES", server.MaxHeaderBytes)
+	if err != nil {
+		return config.Server{}, err
+	}
+
+	return server, nil
+}
+
+// loadDatabase overrides database values with a URL or DSN variable, as in POSTGRES_URL,
+// followed by other variables prefixed by prefix, as in POSTGRES_HOST.
+// URLs must match driver, or the database driver when driver is empty.
+// MongoDB variables, as MONGODB_HOSTS, and SQLite ones, as SQLITE_PATH, are only read for their driver.
+func loadDatabase(prefix string, driver string, db config.Database) (config.Database, error) {
+	var err error
+
+	db, err = loadURL(prefix+"_URL", driver, db)
+	if err != nil {
+		return config.Database{}, err
+	}
+
+	db.Host = getString(prefix+"_HOST", db.Host)
+	db.Port, err = getNumber(prefix+"_PORT", db.Port)
+	if err != nil {
+		return config.Database{}, err
+	}
+	db.Socket = getString(prefix+"_SOCKET", db.Socket)
+	db.User = getString(prefix+"_USER", db.User)
+	db.Password = getString(prefix+"_PASSWORD", db.Password)
+	db.Db = getString(prefix+"_DATABASE", db.Db)
+	db.MigrationsPath = getString(prefix+"_MIGRATIONS_PATH", db.MigrationsPath)
+	db.Driver = getString(prefix+"_DRIVER", db.Driver)
+
+	db.MaxOpenConns, err = getNumber(prefix+"_MAX_OPEN_CONNS", db.MaxOpenConns)
+	if err != nil {
+		return config.Database{}, err
+	}
+	db.MaxIdleConns, err = getNumber(prefix+"_MAX_IDLE_CONNS", db.MaxIdleConns)
+	if err != nil {
+		return config.Database{}, err
+	}
+	db.ConnMaxLifetime, err = getDuration(prefix+"_CONN_MAX_LIFETIME", db.ConnMaxLifetime)
+	if err != nil {
+		return config.Database{}, err
+	}
+	db.ConnMaxIdleTime, err = getDuration(prefix+"_CONN_MAX_IDLE_TIME", db.ConnMaxIdleTime)
+	if err != nil {
+		return config.Database{}, err
+	}
+	db.Replicas, err = getReplicas(prefix+"_REPLICAS", db.Replicas)
+	if err != nil {
+		return config.Database{}, err
+	}
+
+	if driver == "" {
+		driver = db.Driver
+	}
+	if driver == config.DriverMongoDB {
+		db.Hosts = getStringSlice(prefix+"_HOSTS", db.Hosts)
+		db.SRV, err = getBool(prefix+"_SRV", db.SRV)
+		if err != nil {
+			return config.Database{}, err
+		}
+		db.ReplicaSet = getString(prefix+"_REPLICA_SET", db.ReplicaSet)
+	}
+	if driver == config.DriverSQLite {
+		db.Path = getString(prefix+"_PATH", db.Path)
+		db.Pragmas = getStringSlice(prefix+"_PRAGMAS", db.Pragmas)
+	}
+	db.Options = getString(prefix+"_OPTIONS", db.Options)
+
+	return db, nil
+}
+
+// loadMongodb overrides MongoDB values with MONGODB_URI, followed by variables prefixed by MONGODB, as in MONGODB_HOST.
+func loadMongodb(db config.Database) (config.Database, error) {
+	db, err := loadURL("MONGODB_URI", config.DriverMongoDB, db)
+	if err != nil {
+		return config.Database{}, err
+	}
+
+	return loadDatabase("MONGODB", config.DriverMongoDB, db)
+}
+
+// loadDatabaseURL overrides the database matching the DATABASE_URL driver, as Postgres for postgres:// URLs.
+func loadDatabaseURL(cfg config.Config) (config.Config, error) {
+	rawURL := os.Getenv("DATABASE_URL")
+	if rawURL == "" {
+		return cfg, nil
+	}
+
+	parsed, err := config.ParseDatabaseURL(rawURL)
+	if err != nil {
+		return config.Config{}, &config.Error{Source: config.SourceEnv, Key: "DATABASE_URL", Err: err}
+	}
+
+	var db *config.Database
+	switch parsed.Driver {
+	case config.DriverClickHouse:
+		db = &cfg.ClickHouse
+	case config.DriverMongoDB:
+		db = &cfg.MongoDb
+	case config.DriverMySQL:
+		db = &cfg.MySql
+	case config.DriverSQLite:
+		db = &cfg.SQLite
+	case config.DriverSQLServer:
+		db = &cfg.SQLServer
+	default:
+		db = &cfg.Postgres
+	}
+	*db, err = loadURL("DATABASE_URL", parsed.Driver, *db)
+	if err != nil {
+		return config.Config{}, err
 	}
 
 	return cfg, nil
 }
 
+// loadURL applies a URL or DSN variable on db, as in config.Database.ApplyURL.
+func loadURL(key string, driver string, db config.Database) (config.Database, error) {
+	rawURL := os.Getenv(key)
+	if rawURL == "" {
+		return db, nil
+	}
+
+	db.URL = rawURL
+	db, err := db.ApplyURL(driver)
+	if err != nil {
+		return config.Database{}, &config.Error{Source: config.SourceEnv, Key: key, Err: err}
+	}
+	return db, nil
+}
+
+// databaseVariables holds the variable suffixes read by loadDatabase, used to find named databases.
+var databaseVariables = []string{
+	"HOST", "PORT", "SOCKET", "USER", "PASSWORD", "DATABASE", "MIGRATIONS_PATH", "DRIVER",
+	"MAX_OPEN_CONNS", "MAX_IDLE_CONNS", "CONN_MAX_LIFETIME", "CONN_MAX_IDLE_TIME", "REPLICAS",
+	"HOSTS", "SRV", "REPLICA_SET", "OPTIONS", "URL", "PATH", "PRAGMAS",
+}
+
+// loadDatabases overrides named databases with variables prefixed by DATABASES and their name,
+// as in DATABASES_ANALYTICS_HOST. Names match databases case-insensitively, and databases found only
+// in the environment are added.
+func loadDatabases(dbs config.Databases) (config.Databases, error) {
+	names, err := getNames("DATABASES", databaseVariables)
+	if err != nil {
+		return nil, err
+	}
+	if len(dbs) == 0 && len(names) == 0 {
+		return dbs, nil
+	}
+
+	result, variables, err := mergeNames("DATABASES", dbs, names)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, db := range result {
+		db, err := loadDatabase("DATABASES_"+variables[name], "", db)
+		if err != nil {
+			return nil, err
+		}
+		result[name] = db.WithDefaults()
+	}
+
+	return result, nil
+}
+
+// serviceVariables holds the variable suffixes read by loadExternalService, used to find named services.
+var serviceVariables = []string{
+	"ENABLED", "HOST", "TOKEN", "TIMEOUT", "RETRIES", "HEADERS", "TLS_CA_FILE", "TLS_CERT_FILE", "TLS_KEY_FILE",
+}
+
+// loadServices overrides named services with variables prefixed by SERVICES and their name,
+// as in SERVICES_PAYMENTS_HOST. Names match services case-insensitively, and services found only
+// in the environment are added.
+func loadServices(services config.Services) (config.Services, error) {
+	names, err := getNames("SERVICES", serviceVariables)
+	if err != nil {
+		return nil, err
+	}
+	if len(services) == 0 && len(names) == 0 {
+		return services, nil
+	}
+
+	result, variables, err := mergeNames("SERVICES", services, names)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, service := range result {
+		service, err := loadExternalService("SERVICES_"+variables[name], service)
+		if err != nil {
+			return nil, err
+		}
+		result[name] = service.WithDefaults()
+	}
+
+	return result, nil
+}
+
+// loadExternalService overrides service values with variables prefixed by prefix, as in AUDIT_HOST.
+func loadExternalService(prefix string, service config.ExternalService) (config.ExternalService, error) {
+	var err error
+
+	service.Enabled, err = getBool(prefix+"_ENABLED", service.Enabled)
+	if err != nil {
+		return config.ExternalService{}, err
+	}
+	service.Host = getString(prefix+"_HOST", service.Host)
+	service.Token = getString(prefix+"_TOKEN", service.Token)
+	service.Timeout, err = getDuration(prefix+"_TIMEOUT", service.Timeout)
+	if err != nil {
+		return config.ExternalService{}, err
+	}
+	service.Retries, err = getNumber(prefix+"_RETRIES", service.Retries)
+	if err != nil {
+		return config.ExternalService{}, err
+	}
+	service.Headers, err = getAttributes(prefix+"_HEADERS", service.Headers)
+	if err != nil {
+		return config.ExternalService{}, err
+	}
+	service.TLSCAFile = getString(prefix+"_TLS_CA_FILE", service.TLSCAFile)
+	service.TLSCertFile = getString(prefix+"_TLS_CERT_FILE", service.TLSCertFile)
+	service.TLSKeyFile = getString(prefix+"_TLS_KEY_FILE", service.TLSKeyFile)
+
+	return service, nil
+}
+
+// loadToken overrides Token values with variables prefixed by TOKEN, as in TOKEN_ALGORITHM.
+// TOKEN_VERIFICATION_KEYS holds comma separated key_id=secret pairs, and TOKEN_VERIFICATION_KEY_FILES
+// comma separated key_id=public_key_file pairs, as in 2024-01=/etc/keys/2024-01.pem.
+func loadToken(token config.Token) (config.Token, error) {
+	var err error
+
+	token.MaxAge, err = getDuration("TOKEN_MAX_AGE", token.MaxAge)
+	if err != nil {
+		return config.Token{}, err
+	}
+	token.Secret = getString("TOKEN_SECRET", token.Secret)
+	token.Algorithm = getString("TOKEN_ALGORITHM", token.Algorithm)
+	token.Issuer = getString("TOKEN_ISSUER", token.Issuer)
+	token.Audience = getStringSlice("TOKEN_AUDIENCE", token.Audience)
+	token.RefreshMaxAge, err = getDuration("TOKEN_REFRESH_MAX_AGE", token.RefreshMaxAge)
+	if err != nil {
+		return config.Token{}, err
+	}
+	token.KeyID = getString("TOKEN_KEY_ID", token.KeyID)
+	token.PrivateKeyFile = getString("TOKEN_PRIVATE_KEY_FILE", token.PrivateKeyFile)
+	token.PublicKeyFile = getString("TOKEN_PUBLIC_KEY_FILE", token.PublicKeyFile)
+	token.VerificationKeys, err = getTokenKeys(
+		"TOKEN_VERIFICATION_KEYS", "TOKEN_VERIFICATION_KEY_FILES", token.VerificationKeys,
+	)
+	if err != nil {
+		return config.Token{}, err
+	}
+
+	return token, nil
+}
+
+// loadLoki overrides Loki values with variables prefixed by LOKI, as in LOKI_TENANT_ID.
+// LOKI_LABELS holds comma separated name=value pairs, as in team=payments,region=eu.
+func loadLoki(loki config.Loki) (config.Loki, error) {
+	var err error
+
+	loki.Enabled, err = getBool("LOKI_ENABLED", loki.Enabled)
+	if err != nil {
+		return config.Loki{}, err
+	}
+	loki.Host = getString("LOKI_HOST", loki.Host)
+	loki.Token = getString("LOKI_TOKEN", loki.Token)
+	loki.TenantID = getString("LOKI_TENANT_ID", loki.TenantID)
+	loki.Labels, err = getAttributes("LOKI_LABELS", loki.Labels)
+	if err != nil {
+		return config.Loki{}, err
+	}
+	loki.BatchSize, err = getNumber("LOKI_BATCH_SIZE", loki.BatchSize)
+	if err != nil {
+		return config.Loki{}, err
+	}
+	loki.BatchWait, err = getDuration("LOKI_BATCH_WAIT", loki.BatchWait)
+	if err != nil {
+		return config.Loki{}, err
+	}
+	loki.Timeout, err = getDuration("LOKI_TIMEOUT", loki.Timeout)
+	if err != nil {
+		return config.Loki{}, err
+	}
+
+	return loki, nil
+}
+
+// loadPrometheus overrides Prometheus values with variables prefixed by PROMETHEUS, as in PROMETHEUS_PORT.
+func loadPrometheus(prometheus config.Prometheus) (config.Prometheus, error) {
+	var err error
+
+	prometheus.Enabled, err = getBool("PROMETHEUS_ENABLED", prometheus.Enabled)
+	if err != nil {
+		return config.Prometheus{}, err
+	}
+	prometheus.Host = getString("PROMETHEUS_HOST", prometheus.Host)
+	prometheus.Port, err = getNumber("PROMETHEUS_PORT", prometheus.Port)
+	if err != nil {
+		return config.Prometheus{}, err
+	}
+	prometheus.Path = getString("PROMETHEUS_PATH", prometheus.Path)
+	prometheus.Namespace = getString("PROMETHEUS_NAMESPACE", prometheus.Namespace)
+	prometheus.Subsystem = getString("PROMETHEUS_SUBSYSTEM", prometheus.Subsystem)
+	prometheus.BasicAuth.Username = getString("PROMETHEUS_BASIC_AUTH_USERNAME", prometheus.BasicAuth.Username)
+	prometheus.BasicAuth.Password = getString("PROMETHEUS_BASIC_AUTH_PASSWORD", prometheus.BasicAuth.Password)
+	prometheus.Token = getString("PROMETHEUS_TOKEN", prometheus.Token)
+	prometheus.PushgatewayURL = getString("PROMETHEUS_PUSHGATEWAY_URL", prometheus.PushgatewayURL)
+
+	return prometheus, nil
+}
+
+// loadRedis overrides Redis values with REDIS_URL, followed by variables prefixed by REDIS, as in REDIS_HOST.
+func loadRedis(redis config.Redis) (config.Redis, error) {
+	var err error
+
+	if rawURL := os.Getenv("REDIS_URL"); rawURL != "" {
+		var parsed config.Redis
+		parsed, err = config.ParseRedisURL(rawURL)
+		if err != nil {
+			return config.Redis{}, &config.Error{Source: config.SourceEnv, Key: "REDIS_URL", Err: err}
+		}
+		redis.Enabled = parsed.Enabled
+		redis.Host = parsed.Host
+		redis.Username = parsed.Username
+		redis.Password = parsed.Password
+		redis.DB = parsed.DB
+		redis.TLS = parsed.TLS
+	}
+
+	redis.Enabled, err = getBool("REDIS_ENABLED", redis.Enabled)
+	if err != nil {
+		return config.Redis{}, err
+	}
+	redis.Host = getString("REDIS_HOST", redis.Host)
+	redis.Username = getString("REDIS_USERNAME", redis.Username)
+	redis.Password = getString("REDIS_PASSWORD", redis.Password)
+	redis.Token = getString("REDIS_TOKEN", redis.Token)
+	redis.DB, err = getNumber("REDIS_DB", redis.DB)
+	if err != nil {
+		return config.Redis{}, err
+	}
+	redis.TLS, err = getBool("REDIS_TLS", redis.TLS)
+	if err != nil {
+		return config.Redis{}, err
+	}
+
+	redis.PoolSize, err = getNumber("REDIS_POOL_SIZE", redis.PoolSize)
+	if err != nil {
+		return config.Redis{}, err
+	}
+	redis.DialTimeout, err = getDuration("REDIS_DIAL_TIMEOUT", redis.DialTimeout)
+	if err != nil {
+		return config.Redis{}, err
+	}
+	redis.ReadTimeout, err = getDuration("REDIS_READ_TIMEOUT", redis.ReadTimeout)
+	if err != nil {
+		return config.Redis{}, err
+	}
+	redis.WriteTimeout, err = getDuration("REDIS_WRITE_TIMEOUT", redis.WriteTimeout)
+	if err != nil {
+		return config.Redis{}, err
+	}
+
+	redis.MasterName = getString("REDIS_MASTER_NAME", redis.MasterName)
+	redis.SentinelAddresses = getStringSlice("REDIS_SENTINEL_ADDRESSES", redis.SentinelAddresses)
+	redis.ClusterAddresses = getStringSlice("REDIS_CLUSTER_ADDRESSES", redis.ClusterAddresses)
+
+	return redis, nil
+}
+
+// loadOTel overrides OpenTelemetry values with variables prefixed by OTEL, as in OTEL_ENDPOINT,
+// followed by the standard OTEL_* variables of the OpenTelemetry SDK, as in OTEL_EXPORTER_OTLP_ENDPOINT.
+func loadOTel(otel config.OTel) (config.OTel, error) {
+	var err error
+
+	otel.Enabled, err = getBool("OTEL_ENABLED", otel.Enabled)
+	if err != nil {
+		return config.OTel{}, err
+	}
+	otel.ServiceName = getString("OTEL_SERVICE_NAME", otel.ServiceName)
+	otel.ResourceAttributes, err = getAttributes("OTEL_RESOURCE_ATTRIBUTES", otel.ResourceAttributes)
+	if err != nil {
+		return config.OTel{}, err
+	}
+	otel.SampleRatio, err = getFloat("OTEL_SAMPLE_RATIO", otel.SampleRatio)
+	if err != nil {
+		return config.OTel{}, err
+	}
+	otel.MetricsInterval, err = getDuration("OTEL_METRICS_INTERVAL", otel.MetricsInterval)
+	if err != nil {
+		return config.OTel{}, err
+	}
+
+	otel.Endpoint = getString("OTEL_ENDPOINT", otel.Endpoint)
+	otel.Protocol = getString("OTEL_PROTOCOL", otel.Protocol)
+	otel.Headers, err = getAttributes("OTEL_HEADERS", otel.Headers)
+	if err != nil {
+		return config.OTel{}, err
+	}
+	otel.Insecure, err = getBool("OTEL_INSECURE", otel.Insecure)
+	if err != nil {
+		return config.OTel{}, err
+	}
+	otel.Timeout, err = getDuration("OTEL_TIMEOUT", otel.Timeout)
+	if err != nil {
+		return config.OTel{}, err
+	}
+
+	for _, signal := range otelSignals(&otel) {
+		*signal.exporter, err = loadOTelExporter("OTEL_"+signal.name, *signal.exporter)
+		if err != nil {
+			return config.OTel{}, err
+		}
+	}
+
+	return loadOTelStandard(otel)
+}
+
+type otelSignal struct {
+	name     string
+	exporter *config.OTelExporter
+}
+
+// otelSignals returns the exporter of each signal, named as in OTEL_TRACES_EXPORTER.
+func otelSignals(otel *config.OTel) []otelSignal {
+	return []otelSignal{
+		{"TRACES", &otel.Traces},
+		{"METRICS", &otel.Metrics},
+		{"LOGS", &otel.Logs},
+	}
+}
+
+// loadOTelExporter overrides a signal exporter with variables prefixed by prefix, as in OTEL_TRACES_ENDPOINT.
+func loadOTelExporter(prefix string, exporter config.OTelExporter) (config.OTelExporter, error) {
+	var err error
+
+	exporter.Exporter = getString(prefix+"_EXPORTER", exporter.Exporter)
+	exporter.Endpoint = getString(prefix+"_ENDPOINT", exporter.Endpoint)
+	exporter.Protocol = getString(prefix+"_PROTOCOL", exporter.Protocol)
+	exporter.Headers, err = getAttributes(prefix+"_HEADERS", exporter.Headers)
+	if err != nil {
+		return config.OTelExporter{}, err
+	}
+
+	return exporter, nil
+}
+
+// loadOTelStandard overrides OpenTelemetry values with the standard OTEL_* variables of the OpenTelemetry SDK.
+// Timeouts and intervals are given in milliseconds, and samplers are read as sample ratios.
+func loadOTelStandard(otel config.OTel) (config.OTel, error) {
+	disabled, err := getBool("OTEL_SDK_DISABLED", false)
+	if err != nil {
+		return config.OTel{}, err
+	}
+	if disabled {
+		otel.Enabled = false
+	}
+
+	otel.SampleRatio, err = getSampleRatio(otel.SampleRatio)
+	if err != nil {
+		return config.OTel{}, err
+	}
+	otel.MetricsInterval, err = getMilliseconds("OTEL_METRIC_EXPORT_INTERVAL", otel.MetricsInterval)
+	if err != nil {
+		return config.OTel{}, err
+	}
+
+	otel.Endpoint = getString("OTEL_EXPORTER_OTLP_ENDPOINT", otel.Endpoint)
+	otel.Protocol = getString("OTEL_EXPORTER_OTLP_PROTOCOL", otel.Protocol)
+	otel.Headers, err = getAttributes("OTEL_EXPORTER_OTLP_HEADERS", otel.Headers)
+	if err != nil {
+		return config.OTel{}, err
+	}
+	otel.Insecure, err = getBool("OTEL_EXPORTER_OTLP_INSECURE", otel.Insecure)
+	if err != nil {
+		return config.OTel{}, err
+	}
+	otel.Timeout, err = getMilliseconds("OTEL_EXPORTER_OTLP_TIMEOUT", otel.Timeout)
+	if err != nil {
+		return config.OTel{}, err
+	}
+
+	for _, signal := range otelSignals(&otel) {
+		signal.exporter.Endpoint = getString("OTEL_EXPORTER_OTLP_"+signal.name+"_ENDPOINT", signal.exporter.Endpoint)
+		signal.exporter.Protocol = getString("OTEL_EXPORTER_OTLP_"+signal.name+"_PROTOCOL", signal.exporter.Protocol)
+		signal.exporter.Headers, err = getAttributes("OTEL_EXPORTER_OTLP_"+signal.name+"_HEADERS", signal.exporter.Headers)
+		if err != nil {
+			return config.OTel{}, err
+		}
+	}
+
+	return otel, nil
+}
+
+// loadLogging overrides logging values with variables prefixed by LOG, as in LOG_LEVEL.
+// LOG_LEVELS holds comma separated package=level pairs, as in example.com/app/db=debug.
+func loadLogging(logging config.Logging) (config.Logging, error) {
+	var err error
+
+	logging.Level = getString("LOG_LEVEL", logging.Level)
+	logging.Format = getString("LOG_FORMAT", logging.Format)
+	logging.Output = getString("LOG_OUTPUT", logging.Output)
+	logging.Source, err = getBool("LOG_SOURCE", logging.Source)
+	if err != nil {
+		return config.Logging{}, err
+	}
+	logging.Levels, err = getAttributes("LOG_LEVELS", logging.Levels)
+	if err != nil {
+		return config.Logging{}, err
+	}
+
+	return logging, nil
+}
+
+// loadKafka overrides Kafka values with variables prefixed by KAFKA, as in KAFKA_BROKERS or KAFKA_SASL_USERNAME.
+func loadKafka(kafka config.Kafka) (config.Kafka, error) {
+	var err error
+
+	kafka.Enabled, err = getBool("KAFKA_ENABLED", kafka.Enabled)
+	if err != nil {
+		return config.Kafka{}, err
+	}
+	kafka.Brokers = getStringSlice("KAFKA_BROKERS", kafka.Brokers)
+	kafka.ClientID = getString("KAFKA_CLIENT_ID", kafka.ClientID)
+	kafka.ConsumerGroup = getString("KAFKA_CONSUMER_GROUP", kafka.ConsumerGroup)
+	kafka.DialTimeout, err = getDuration("KAFKA_DIAL_TIMEOUT", kafka.DialTimeout)
+	if err != nil {
+		return config.Kafka{}, err
+	}
+
+	kafka.SASL.Mechanism = getString("KAFKA_SASL_MECHANISM", kafka.SASL.Mechanism)
+	kafka.SASL.Username = getString("KAFKA_SASL_USERNAME", kafka.SASL.Username)
+	kafka.SASL.Password = getString("KAFKA_SASL_PASSWORD", kafka.SASL.Password)
+
+	kafka.TLS, err = getBool("KAFKA_TLS", kafka.TLS)
+	if err != nil {
+		return config.Kafka{}, err
+	}
+	kafka.TLSCAFile = getString("KAFKA_TLS_CA_FILE", kafka.TLSCAFile)
+	kafka.TLSCertFile = getString("KAFKA_TLS_CERT_FILE", kafka.TLSCertFile)
+	kafka.TLSKeyFile = getString("KAFKA_TLS_KEY_FILE", kafka.TLSKeyFile)
+
+	return kafka, nil
+}
+
+// loadNATS overrides NATS values with variables prefixed by NATS, as in NATS_URLS.
+func loadNATS(nats config.NATS) (config.NATS, error) {
+	var err error
+
+	nats.Enabled, err = getBool("NATS_ENABLED", nats.Enabled)
+	if err != nil {
+		return config.NATS{}, err
+	}
+	nats.URLs = getStringSlice("NATS_URLS", nats.URLs)
+	nats.Name = getString("NATS_NAME", nats.Name)
+	nats.CredentialsFile = getString("NATS_CREDENTIALS_FILE", nats.CredentialsFile)
+	nats.ConnectTimeout, err = getDuration("NATS_CONNECT_TIMEOUT", nats.ConnectTimeout)
+	if err != nil {
+		return config.NATS{}, err
+	}
+
+	return nats, nil
+}
+
+// loadRabbitMQ overrides RabbitMQ values with RABBITMQ_URL, followed by variables prefixed by RABBITMQ,
+// as in RABBITMQ_HOST.
+func loadRabbitMQ(rabbitMQ config.RabbitMQ) (config.RabbitMQ, error) {
+	var err error
+
+	if rawURL := os.Getenv("RABBITMQ_URL"); rawURL != "" {
+		var parsed config.RabbitMQ
+		parsed, err = config.ParseRabbitMQURL(rawURL)
+		if err != nil {
+			return config.RabbitMQ{}, &config.Error{Source: config.SourceEnv, Key: "RABBITMQ_URL", Err: err}
+		}
+		rabbitMQ.Enabled = parsed.Enabled
+		rabbitMQ.Host = parsed.Host
+		rabbitMQ.Port = parsed.Port
+		rabbitMQ.User = parsed.User
+		rabbitMQ.Password = parsed.Password
+		rabbitMQ.VHost = parsed.VHost
+		rabbitMQ.TLS = parsed.TLS
+	}
+
+	rabbitMQ.Enabled, err = getBool("RABBITMQ_ENABLED", rabbitMQ.Enabled)
+	if err != nil {
+		return config.RabbitMQ{}, err
+	}
+	rabbitMQ.Host = getString("RABBITMQ_HOST", rabbitMQ.Host)
+	rabbitMQ.Port, err = getNumber("RABBITMQ_PORT", rabbitMQ.Port)
+	if err != nil {
+		return config.RabbitMQ{}, err
+	}
+	rabbitMQ.User = getString("RABBITMQ_USER", rabbitMQ.User)
+	rabbitMQ.Password = getString("RABBITMQ_PASSWORD", rabbitMQ.Password)
+	rabbitMQ.VHost = getString("RABBITMQ_VHOST", rabbitMQ.VHost)
+	rabbitMQ.TLS, err = getBool("RABBITMQ_TLS", rabbitMQ.TLS)
+	if err != nil {
+		return config.RabbitMQ{}, err
+	}
+	rabbitMQ.Heartbeat, err = getDuration("RABBITMQ_HEARTBEAT", rabbitMQ.Heartbeat)
+	if err != nil {
+		return config.RabbitMQ{}, err
+	}
+
+	return rabbitMQ, nil
+}
+
+func getString(key string, defaultVal string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultVal
+	}
+	return value
+}
+
+func getStringSlice(key string, defaultVal []string) []string {
+	rawValue := os.Getenv(key)
+	if rawValue == "" {
+		return defaultVal
+	}
+	return strings.Split(rawValue, ",")
+}
+
 func getNumber(key string, defaultVal int) (int, error) {
 	rawIntValue := os.Getenv(key)
 	if rawIntValue == "" {
@@ -175,20 +812,215 @@ func getNumber(key string, defaultVal int) (int, error) {
 	}
 	intValue, err := strconv.Atoi(rawIntValue)
 	if err != nil {
-		return defaultInt, fmt.Errorf("invalid %s int value: %s", key, err.Error())
+		return defaultInt, &config.Error{Source: config.SourceEnv, Key: key, Err: fmt.Errorf("invalid int value: %w", err)}
 	}
 	return intValue, nil
 }
 
-func getBool(key string) (bool, error) {
+func getDuration(key string, defaultVal config.Duration) (config.Duration, error) {
+	rawDurationValue := os.Getenv(key)
+	if rawDurationValue == "" {
+		return defaultVal, nil
+	}
+	durationValue, err := config.ParseDuration(rawDurationValue)
+	if err != nil {
+		return 0, &config.Error{Source: config.SourceEnv, Key: key, Err: err}
+	}
+	return durationValue, nil
+}
+
+func getFloat(key string, defaultVal float64) (float64, error) {
+	rawFloatValue := os.Getenv(key)
+	if rawFloatValue == "" {
+		return defaultVal, nil
+	}
+	floatValue, err := strconv.ParseFloat(rawFloatValue, 64)
+	if err != nil {
+		return 0, &config.Error{Source: config.SourceEnv, Key: key, Err: fmt.Errorf("invalid float value: %w", err)}
+	}
+	return floatValue, nil
+}
+
+// getMilliseconds reads a duration given as an integer number of milliseconds, as in OTEL_EXPORTER_OTLP_TIMEOUT.
+func getMilliseconds(key string, defaultVal config.Duration) (config.Duration, error) {
+	milliseconds, err := getNumber(key, -1)
+	if err != nil || milliseconds == -1 {
+		return defaultVal, err
+	}
+	return config.Duration(time.Duration(milliseconds) * time.Millisecond), nil
+}
+
+// getSampleRatio reads OTEL_TRACES_SAMPLER, along with OTEL_TRACES_SAMPLER_ARG for ratio based samplers.
+// Other samplers, as jaeger_remote or xray, are left to the SDK and keep defaultVal.
+func getSampleRatio(defaultVal float64) (float64, error) {
+	switch sampler := os.Getenv("OTEL_TRACES_SAMPLER"); sampler {
+	case "":
+		return defaultVal, nil
+	case "always_on", "parentbased_always_on":
+		return 1, nil
+	case "always_off", "parentbased_always_off":
+		return 0, nil
+	case "traceidratio", "parentbased_traceidratio":
+		return getFloat("OTEL_TRACES_SAMPLER_ARG", 1)
+	default:
+		return defaultVal, nil
+	}
+}
+
+// getAttributes reads a comma separated list of key=value pairs with percent-encoded values, as in OTEL_RESOURCE_ATTRIBUTES.
+// Pairs are added to defaultVal, replacing values of the same key.
+func getAttributes(key string, defaultVal config.Attributes) (config.Attributes, error) {
+	rawAttributesValue := os.Getenv(key)
+	if rawAttributesValue == "" {
+		return defaultVal, nil
+	}
+
+	attributes := maps.Clone(defaultVal)
+	if attributes == nil {
+		attributes = make(config.Attributes)
+	}
+	for _, pair := range strings.Split(rawAttributesValue, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, &config.Error{Source: config.SourceEnv, Key: key, Err: fmt.Errorf("invalid key=value pair %q", pair)}
+		}
+		value, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, &config.Error{Source: config.SourceEnv, Key: key, Err: fmt.Errorf("invalid value of %q: %w", name, err)}
+		}
+		attributes[name] = value
+	}
+	return attributes, nil
+}
+
+// getTokenKeys reads token keys from key_id=secret pairs of secretsKey and key_id=public_key_file pairs of filesKey,
+// merging the pairs of the same key identifier. Keys are sorted by key identifier.
+func getTokenKeys(secretsKey string, filesKey string, defaultVal []config.TokenKey) ([]config.TokenKey, error) {
+	if os.Getenv(secretsKey) == "" && os.Getenv(filesKey) == "" {
+		return defaultVal, nil
+	}
+
+	secrets, err := getAttributes(secretsKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	files, err := getAttributes(filesKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	keyIDs := slices.Collect(maps.Keys(secrets))
+	for keyID := range files {
+		if _, ok := secrets[keyID]; !ok {
+			keyIDs = append(keyIDs, keyID)
+		}
+	}
+	slices.Sort(keyIDs)
+
+	keys := make([]config.TokenKey, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		keys = append(keys, config.TokenKey{KeyID: keyID, Secret: secrets[keyID], PublicKeyFile: files[keyID]})
+	}
+	return keys, nil
+}
+
+func getReplicas(key string, defaultVal []config.Replica) ([]config.Replica, error) {
+	rawReplicasValue := os.Getenv(key)
+	if rawReplicasValue == "" {
+		return defaultVal, nil
+	}
+	replicas, err := config.ParseReplicas(rawReplicasValue)
+	if err != nil {
+		return nil, &config.Error{Source: config.SourceEnv, Key: key, Err: err}
+	}
+	return replicas, nil
+}
+
+func getBool(key string, defaultVal bool) (bool, error) {
 	rawBoolValue := os.Getenv(key)
 	switch rawBoolValue {
 	case "1", "true", "TRUE", "True":
 		return true, nil
-	case "0", "false", "FALSE", "False", "":
+	case "0", "false", "FALSE", "False":
 		return false, nil
+	case "":
+		return defaultVal, nil
+	}
+	return false, &config.Error{Source: config.SourceEnv, Key: key, Err: fmt.Errorf("invalid bool value: %s", rawBoolValue)}
+}
+
+// getNames returns the names of variables set as <prefix>_<NAME>_<SUFFIX>, for any given suffix,
+// as written in variables and keyed by their lower case form.
+// Longer suffixes are matched first, so names can't swallow part of a suffix like MIGRATIONS_PATH.
+// Names differing only by case, as in SERVICES_Foo_HOST and SERVICES_FOO_HOST, are rejected.
+func getNames(prefix string, suffixes []string) (map[string]string, error) {
+	suffixes = slices.Clone(suffixes)
+	slices.SortFunc(suffixes, func(a, b string) int { return len(b) - len(a) })
+
+	variables := os.Environ()
+	slices.Sort(variables)
+
+	names := make(map[string]string)
+	for _, variable := range variables {
+		key, value, _ := strings.Cut(variable, "=")
+		rest, ok := strings.CutPrefix(key, prefix+"_")
+		if !ok || value == "" {
+			continue
+		}
+		for _, suffix := range suffixes {
+			name, ok := strings.CutSuffix(rest, "_"+suffix)
+			if !ok || name == "" {
+				continue
+			}
+			if other, ok := names[strings.ToLower(name)]; ok && other != name {
+				err := fmt.Errorf("name %s differs only by case from %s", name, other)
+				return nil, &config.Error{Source: config.SourceEnv, Key: key, Err: err}
+			}
+			names[strings.ToLower(name)] = name
+			break
+		}
 	}
-	return false, fmt.Errorf("invalid %s bool value: %s", key, rawBoolValue)
+
+	return names, nil
+}
+
+// mergeNames returns a copy of entries, with an empty entry added for each name found in the environment
+// unless it matches an entry case-insensitively, as DATABASES_ANALYTICS_HOST does with Analytics,
+// along with the name written in variables for each entry. Names matching several entries are rejected.
+func mergeNames[M ~map[string]T, T any](prefix string, entries M, names map[string]string) (M, map[string]string, error) {
+	result := make(M, len(entries)+len(names))
+	variables := make(map[string]string, len(entries)+len(names))
+	for key, entry := range entries {
+		result[key] = entry
+		variables[key] = strings.ToUpper(key)
+	}
+
+	for _, lower := range slices.Sorted(maps.Keys(names)) {
+		name := names[lower]
+
+		var matches []string
+		for key := range entries {
+			if strings.EqualFold(key, name) {
+				matches = append(matches, key)
+			}
+		}
+		slices.Sort(matches)
+
+		switch len(matches) {
+		case 0:
+			var entry T
+			result[lower] = entry
+			variables[lower] = name
+		case 1:
+			variables[matches[0]] = name
+		default:
+			err := fmt.Errorf("name %s matches %s", name, strings.Join(matches, " and "))
+			return nil, nil, &config.Error{Source: config.SourceEnv, Key: prefix + "_" + name, Err: err}
+		}
+	}
+
+	return result, variables, nil
 }
 
 func getStringMap(envVar string) map[string]string {
@@ -210,3 +1042,11 @@ func getStringMap(envVar string) map[string]string {
 
 	return result
 }
+
+func mergeStringMap(base map[string]string, overrides map[string]string) map[string]string {
+	result := make(map[string]string, len(base)+len(overrides))
+	maps.Copy(result, base)
+	maps.Copy(result, overrides)
+
+	return result
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SourceEnv is the Error source of values read from the OS environment.
+const SourceEnv = "env"
+
+// Error describes an error loading configurations, with its source and location when available.
+// It wraps the underlying decoder or parsing error, which can be inspected with errors.Is and errors.As.
+type Error struct {
+	// Source is the loaded file path, or SourceEnv. It is empty when loading content.
+	Source string
+	// Key is the config key path, as in "server.port", or the environment variable name.
+	Key string
+	// Line and Column locate the error in the loaded content, starting at 1. They are 0 when unknown.
+	Line   int
+	Column int
+	// Err is the underlying error.
+	Err error
+	// Message replaces the message of Err when set, as for decoder errors already holding their line and key.
+	Message string
+}
+
+// Error returns the error location, key and cause, as in "config.yml:3:9: server.port: <cause>".
+func (e *Error) Error() string {
+	var builder strings.Builder
+
+	switch {
+	case e.Source != "":
+		builder.WriteString(e.Source)
+		if e.Line > 0 {
+			_, _ = fmt.Fprintf(&builder, ":%d", e.Line)
+		}
+		if e.Line > 0 && e.Column > 0 {
+			_, _ = fmt.Fprintf(&builder, ":%d", e.Column)
+		}
+		builder.WriteString(": ")
+	case e.Line > 0 && e.Column > 0:
+		_, _ = fmt.Fprintf(&builder, "line %d, column %d: ", e.Line, e.Column)
+	case e.Line > 0:
+		_, _ = fmt.Fprintf(&builder, "line %d: ", e.Line)
+	}
+
+	if e.Key != "" {
+		builder.WriteString(e.Key)
+		builder.WriteString(": ")
+	}
+	switch {
+	case e.Message != "":
+		builder.WriteString(e.Message)
+	case e.Err != nil:
+		builder.WriteString(e.Err.Error())
+	}
+
+	return builder.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Error(t *testing.T) {
+	cause := errors.New("invalid value")
+
+	tests := map[string]struct {
+		err      Error
+		expected string
+	}{
+		"with source, location and key": {
+			err:      Error{Source: "config.yml", Key: "server.port", Line: 3, Column: 9, Err: cause},
+			expected: "config.yml:3:9: server.port: invalid value",
+		},
+		"with source and line": {
+			err:      Error{Source: "config.toml", Line: 3, Err: cause},
+			expected: "config.toml:3: invalid value",
+		},
+		"with environment source": {
+			err:      Error{Source: SourceEnv, Key: "SERVER_PORT", Err: cause},
+			expected: "env: SERVER_PORT: invalid value",
+		},
+		"without source": {
+			err:      Error{Key: "server.port", Line: 3, Column: 9, Err: cause},
+			expected: "line 3, column 9: server.port: invalid value",
+		},
+		"with message replacing the cause": {
+			err:      Error{Source: "config.toml", Key: "server.port", Line: 2, Err: cause, Message: "toml: invalid type"},
+			expected: "config.toml:2: server.port: toml: invalid type",
+		},
+		"with cause only": {
+			err:      Error{Err: cause},
+			expected: "invalid value",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.err.Error())
+		})
+	}
+}
+
+func TestError_Unwrap(t *testing.T) {
+	err := error(&Error{Source: "config.toml", Err: fs.ErrNotExist})
+
+	t.Run("should support errors.Is and errors.As", func(t *testing.T) {
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+
+		var cfgErr *Error
+		assert.ErrorAs(t, err, &cfgErr)
+		assert.Equal(t, "config.toml", cfgErr.Source)
+	})
+}
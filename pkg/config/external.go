@@ -0,0 +1,187 @@
+package config
+
+import (
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ErrUnknownService is returned when looking up a named service missing from Services.
+var ErrUnknownService = errors.New("unknown service")
+
+// Services holds named external services, as in services.payments, also supported by the xml loader.
+type Services map[string]ExternalService
+
+// externalServiceRetryWait is the wait before the first retry of a call, doubled on each following retry.
+const externalServiceRetryWait = 100 * time.Millisecond
+
+// idempotencyKeyHeader marks requests safe to retry, whatever their method.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotentMethods holds the methods of requests retried without an idempotency key.
+var idempotentMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete,
+}
+
+// ExternalService returns a named service from Services.
+func (c Config) ExternalService(name string) (ExternalService, error) {
+	service, ok := c.Services[name]
+	if !ok {
+		return ExternalService{}, fmt.Errorf("%w: %q", ErrUnknownService, name)
+	}
+	return service, nil
+}
+
+// WithDefaults returns the service with an unset Timeout taken from Default(), as for named services.
+func (s ExternalService) WithDefaults() ExternalService {
+	if s.Timeout == 0 {
+		s.Timeout = Duration(DefaultExternalServiceTimeout * time.Second)
+	}
+	return s
+}
+
+// ServiceHTTPClient returns the HTTP client of a named service, as built by ExternalService.HTTPClient.
+func (c Config) ServiceHTTPClient(name string) (*http.Client, error) {
+	service, err := c.ExternalService(name)
+	if err != nil {
+		return nil, err
+	}
+	return service.HTTPClient()
+}
+
+// UnmarshalXML decodes each nested element as a service named after the element.
+func (s *Services) UnmarshalXML(decoder *xml.Decoder, _ xml.StartElement) error {
+	return unmarshalXMLMap(decoder, (*map[string]ExternalService)(s))
+}
+
+// MarshalXML encodes each service as an element named after the service.
+func (s Services) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLMap(encoder, start, s)
+}
+
+// HTTPClient returns an HTTP client calling the service with Timeout, Headers and the bearer Token, when set.
+// Calls failing with a network error or a 429, 502, 503 or 504 status are retried up to Retries times,
+// when idempotent, as GET, PUT or DELETE calls or calls with an Idempotency-Key header, and unless their body
+// can't be sent again. Retries wait for the Retry-After header of 429 and 503 responses, when set.
+// TLS connections verify the service with TLSCAFile and present the client certificate, when set.
+func (s ExternalService) HTTPClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if s.TLSCAFile != "" || s.TLSCertFile != "" || s.TLSKeyFile != "" {
+		tlsConfig, err := clientTLSConfig(s.TLSCAFile, s.TLSCertFile, s.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{
+		Timeout: s.Timeout.Duration(),
+		Transport: &serviceTransport{
+			next:    transport,
+			headers: s.Headers,
+			token:   s.Token,
+			retries: s.Retries,
+		},
+	}, nil
+}
+
+// serviceTransport adds static headers to requests and retries failed ones.
+type serviceTransport struct {
+	next    http.RoundTripper
+	headers Attributes
+	token   string
+	retries int
+}
+
+func (t *serviceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+	if t.token != "" && req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+
+	retries := t.retries
+	if !idempotent(req) || (req.Body != nil && req.GetBody == nil) {
+		retries = 0
+	}
+
+	wait := externalServiceRetryWait
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= retries || !retryable(resp, err) {
+			return resp, err
+		}
+		delay := wait
+		if resp != nil {
+			if retryAfter, ok := retryAfter(resp); ok {
+				delay = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		wait *= 2
+
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// idempotent reports whether a request can be sent again without side effects.
+func idempotent(req *http.Request) bool {
+	method := cmp.Or(req.Method, http.MethodGet)
+	return slices.Contains(idempotentMethods, method) || req.Header.Get(idempotencyKeyHeader) != ""
+}
+
+// retryAfter returns the wait asked by the Retry-After header of 429 and 503 responses,
+// given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(time.Until(date), 0), true
+}
+
+// retryable reports whether a call failed with a network error or a status telling the service is unavailable.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"encoding/pem"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalService_HTTPClient(t *testing.T) {
+	t.Run("should send headers and token", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer audit.token", r.Header.Get("Authorization"))
+			assert.Equal(t, "orders", r.Header.Get("X-Source"))
+			assert.Equal(t, "request", r.Header.Get("X-Request"))
+		}))
+		defer server.Close()
+
+		service := ExternalService{
+			Token:   "audit.token",
+			Timeout: Duration(time.Second),
+			Headers: Attributes{"X-Source": "orders", "X-Request": "static"},
+		}
+		client, err := service.HTTPClient()
+		require.NoError(t, err)
+		assert.Equal(t, time.Second, client.Timeout)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Request", "request")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+
+	t.Run("should retry unavailable services", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "event", string(body))
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		client, err := ExternalService{Retries: 2}.HTTPClient()
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("event"))
+		require.NoError(t, err)
+		req.Header.Set("Idempotency-Key", "event-1")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("should not retry calls which are not idempotent", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client, err := ExternalService{Retries: 2}.HTTPClient()
+		require.NoError(t, err)
+
+		resp, err := client.Post(server.URL, "text/plain", strings.NewReader("event"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("should wait for the Retry-After header", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+		}))
+		defer server.Close()
+
+		client, err := ExternalService{Retries: 1}.HTTPClient()
+		require.NoError(t, err)
+
+		start := time.Now()
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("should return the last response after every retry", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		client, err := ExternalService{Retries: 1}.HTTPClient()
+		require.NoError(t, err)
+
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("should not retry other errors", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client, err := ExternalService{Retries: 3}.HTTPClient()
+		require.NoError(t, err)
+
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("should verify the service with the CA certificate", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		caFile := filepath.Join(t.TempDir(), "ca.crt")
+		certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		require.NoError(t, os.WriteFile(caFile, certificate, 0o600))
+
+		client, err := ExternalService{TLSCAFile: caFile}.HTTPClient()
+		require.NoError(t, err)
+
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should return an error for invalid certificates", func(t *testing.T) {
+		_, err := ExternalService{TLSCAFile: filepath.Join(t.TempDir(), "missing.crt")}.HTTPClient()
+		assert.ErrorContains(t, err, "loading TLS CA certificate")
+	})
+}
+
+func TestExternalService_WithDefaults(t *testing.T) {
+	t.Run("should set the default timeout", func(t *testing.T) {
+		service := ExternalService{Host: "https://payments.domain"}.WithDefaults()
+		assert.Equal(t, Duration(DefaultExternalServiceTimeout*time.Second), service.Timeout)
+	})
+
+	t.Run("should keep the given timeout", func(t *testing.T) {
+		service := ExternalService{Timeout: Duration(time.Second)}.WithDefaults()
+		assert.Equal(t, Duration(time.Second), service.Timeout)
+	})
+}
+
+func TestConfig_ExternalService(t *testing.T) {
+	cfg := Config{
+		Services: Services{
+			"payments": {Enabled: true, Host: "https://payments.domain", Timeout: Duration(time.Second)},
+		},
+	}
+
+	t.Run("should return a named service", func(t *testing.T) {
+		service, err := cfg.ExternalService("payments")
+		require.NoError(t, err)
+		assert.Equal(t, "https://payments.domain", service.Host)
+
+		client, err := cfg.ServiceHTTPClient("payments")
+		require.NoError(t, err)
+		assert.Equal(t, time.Second, client.Timeout)
+	})
+
+	t.Run("should return an error for unknown services", func(t *testing.T) {
+		_, err := cfg.ExternalService("shipping")
+		assert.ErrorIs(t, err, ErrUnknownService)
+		assert.EqualError(t, err, `unknown service: "shipping"`)
+
+		_, err = cfg.ServiceHTTPClient("shipping")
+		assert.ErrorIs(t, err, ErrUnknownService)
+	})
+}
+
+func TestServices_XML(t *testing.T) {
+	t.Run("should decode and encode services named after elements", func(t *testing.T) {
+		content := `<config><services><payments><enabled>true</enabled><host>https://payments.domain</host>` +
+			`</payments></services></config>`
+
+		var decoded struct {
+			XMLName  xml.Name `xml:"config"`
+			Services Services `xml:"services"`
+		}
+		err := xml.Unmarshal([]byte(content), &decoded)
+		require.NoError(t, err)
+		assert.Equal(t, Services{"payments": {Enabled: true, Host: "https://payments.domain"}}, decoded.Services)
+
+		encoded, err := xml.Marshal(decoded)
+		require.NoError(t, err)
+
+		var roundTrip struct {
+			XMLName  xml.Name `xml:"config"`
+			Services Services `xml:"services"`
+		}
+		err = xml.Unmarshal(encoded, &roundTrip)
+		require.NoError(t, err)
+		assert.Equal(t, decoded.Services, roundTrip.Services)
+	})
+}
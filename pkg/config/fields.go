@@ -0,0 +1,66 @@
+package config
+
+import (
+	"cmp"
+	"reflect"
+	"strings"
+)
+
+// Field describes a configuration field, from its struct tags and default value.
+type Field struct {
+	// Key is the field name used by every file format, as in "migrations_path".
+	Key string
+	// Description documents the field, from its desc tag.
+	Description string
+	// Required tells whether the field must be set, from its required tag.
+	Required bool
+	// Type is the Go type of the field.
+	Type reflect.Type
+	// Default is the field value returned by Default.
+	Default any
+	// Fields holds nested fields, for struct types and maps or slices of structs, as in named databases.
+	Fields []Field
+}
+
+// Fields describes every Config field, with default values taken from Default.
+func Fields() []Field {
+	return describe(reflect.ValueOf(Default()), "")
+}
+
+// describe returns the fields of a struct value. Fields tagged with a driver, as driver:"mongodb",
+// are left out of database blocks of another driver, while named databases keep every field.
+func describe(value reflect.Value, driver string) []Field {
+	valueType := value.Type()
+	fields := make([]Field, 0, valueType.NumField())
+
+	for i := range valueType.NumField() {
+		structField := valueType.Field(i)
+		key, _, _ := strings.Cut(structField.Tag.Get("toml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		fieldDriver := cmp.Or(structField.Tag.Get("driver"), driver)
+		if driver != "" && fieldDriver != driver {
+			continue
+		}
+
+		field := Field{
+			Key:         key,
+			Description: structField.Tag.Get("desc"),
+			Required:    structField.Tag.Get("required") == "true",
+			Type:        structField.Type,
+			Default:     value.Field(i).Interface(),
+		}
+		switch {
+		case structField.Type.Kind() == reflect.Struct:
+			field.Fields = describe(value.Field(i), fieldDriver)
+		case structField.Type.Kind() == reflect.Map && structField.Type.Elem().Kind() == reflect.Struct,
+			structField.Type.Kind() == reflect.Slice && structField.Type.Elem().Kind() == reflect.Struct:
+			field.Fields = describe(reflect.New(structField.Type.Elem()).Elem(), "")
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFields(t *testing.T) {
+	fields := Fields()
+
+	t.Run("should describe fields from struct tags", func(t *testing.T) {
+		server := findField(t, fields, "server")
+		assert.Equal(t, "HTTP server configuration.", server.Description)
+		assert.Equal(t, reflect.Struct, server.Type.Kind())
+
+		host := findField(t, server.Fields, "host")
+		assert.Equal(t, "HTTP server host name.", host.Description)
+		assert.Equal(t, "", host.Default)
+	})
+
+	t.Run("should hold default values", func(t *testing.T) {
+		postgres := findField(t, fields, "postgres")
+
+		port := findField(t, postgres.Fields, "port")
+		assert.Equal(t, DefaultPostgresPort, port.Default)
+
+		migrationsPath := findField(t, postgres.Fields, "migrations_path")
+		assert.Equal(t, DefaultMigrationsPostgres, migrationsPath.Default)
+	})
+
+	t.Run("should use database as key for database name", func(t *testing.T) {
+		mysql := findField(t, fields, "mysql")
+		db := findField(t, mysql.Fields, "database")
+		assert.Equal(t, "Database name.", db.Description)
+	})
+
+	t.Run("should leave driver fields out of other database blocks", func(t *testing.T) {
+		mongodb := findField(t, fields, "mongodb")
+		findField(t, mongodb.Fields, "replica_set")
+		sqlite := findField(t, fields, "sqlite")
+		findField(t, sqlite.Fields, "pragmas")
+
+		postgres := findField(t, fields, "postgres")
+		for _, field := range postgres.Fields {
+			assert.NotContains(t, []string{"hosts", "srv", "replica_set", "path", "pragmas"}, field.Key)
+		}
+
+		databases := findField(t, fields, "databases")
+		findField(t, databases.Fields, "replica_set")
+	})
+}
+
+func findField(t *testing.T, fields []Field, key string) Field {
+	t.Helper()
+
+	for _, field := range fields {
+		if field.Key == key {
+			return field
+		}
+	}
+	require.Failf(t, "field not found", "key %s", key)
+	return Field{}
+}
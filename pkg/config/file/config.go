@@ -0,0 +1,93 @@
+package file
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ribeirohugo/go_config/v2/pkg/config"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/dotenv"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/json"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/toml"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/xml"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/yaml"
+)
+
+// Supported file formats.
+const (
+	FormatDotenv = "env"
+	FormatJSON   = "json"
+	FormatTOML   = "toml"
+	FormatXML    = "xml"
+	FormatYAML   = "yaml"
+)
+
+// Format returns the configuration format of a given file path, based on its extension.
+// Files named ".env" or with an ".env" extension are handled as dotenv files.
+func Format(filePath string) (string, error) {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filePath), "."))
+	switch ext {
+	case "env":
+		return FormatDotenv, nil
+	case "json":
+		return FormatJSON, nil
+	case "toml":
+		return FormatTOML, nil
+	case "xml":
+		return FormatXML, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	}
+	return "", fmt.Errorf("unsupported config file format: %s", filePath)
+}
+
+// Load loads configurations from a given file path, using the loader matching its extension.
+func Load(filePath string) (config.Config, error) {
+	format, err := Format(filePath)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	switch format {
+	case FormatDotenv:
+		return dotenv.Load(filePath)
+	case FormatJSON:
+		return json.Load(filePath)
+	case FormatTOML:
+		return toml.Load(filePath)
+	case FormatXML:
+		cfg, err := xml.Load(filePath)
+		if err != nil {
+			return config.Config{}, err
+		}
+		return cfg.Config, nil
+	default:
+		return yaml.Load(filePath)
+	}
+}
+
+// LoadStrict loads configurations from a given file path, returning an error for unknown keys.
+// Dotenv files are loaded as in Load, since they may hold variables used by other applications.
+func LoadStrict(filePath string) (config.Config, error) {
+	format, err := Format(filePath)
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	switch format {
+	case FormatDotenv:
+		return dotenv.Load(filePath)
+	case FormatJSON:
+		return json.LoadStrict(filePath)
+	case FormatTOML:
+		return toml.LoadStrict(filePath)
+	case FormatXML:
+		cfg, err := xml.LoadStrict(filePath)
+		if err != nil {
+			return config.Config{}, err
+		}
+		return cfg.Config, nil
+	default:
+		return yaml.LoadStrict(filePath)
+	}
+}
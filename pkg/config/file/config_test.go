@@ -0,0 +1,101 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ribeirohugo/go_config/v2/pkg/config"
+)
+
+func TestFormat(t *testing.T) {
+	tests := map[string]string{
+		"config.toml": FormatTOML,
+		"config.yml":  FormatYAML,
+		"config.YAML": FormatYAML,
+		"config.json": FormatJSON,
+		"config.xml":  FormatXML,
+		".env":        FormatDotenv,
+		"prod.env":    FormatDotenv,
+	}
+
+	t.Run("should return the file format", func(t *testing.T) {
+		for filePath, expectedFormat := range tests {
+			format, err := Format(filePath)
+			require.NoError(t, err)
+			assert.Equal(t, expectedFormat, format, filePath)
+		}
+	})
+
+	t.Run("should return an error for unsupported formats", func(t *testing.T) {
+		_, err := Format("config.ini")
+		assert.Error(t, err)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	contents := map[string]string{
+		"config.toml": "[server]\nhost = \"localhost\"\nport = 8080\n",
+		"config.yml":  "server:\n  host: localhost\n  port: 8080\n",
+		"config.json": `{"server": {"host": "localhost", "port": 8080}}`,
+		"config.xml":  "<config><server><host>localhost</host><port>8080</port></server></config>",
+	}
+
+	t.Run("should load config with the matching loader", func(t *testing.T) {
+		for fileName, content := range contents {
+			filePath := filepath.Join(t.TempDir(), fileName)
+			err := os.WriteFile(filePath, []byte(content), 0o600)
+			require.NoError(t, err)
+
+			cfg, err := Load(filePath)
+			require.NoError(t, err, fileName)
+			assert.Equal(t, "localhost", cfg.Server.Host, fileName)
+			assert.Equal(t, 8080, cfg.Server.Port, fileName)
+			assert.Equal(t, config.DefaultPostgresPort, cfg.Postgres.Port, fileName)
+		}
+	})
+
+	t.Run("with error return", func(t *testing.T) {
+		t.Run("unsupported format", func(t *testing.T) {
+			cfg, err := Load("config.ini")
+			assert.Equal(t, config.Config{}, cfg)
+			assert.Error(t, err)
+		})
+
+		t.Run("file doesn't exist", func(t *testing.T) {
+			cfg, err := Load("missing.xml")
+			assert.Equal(t, config.Config{}, cfg)
+			assert.Error(t, err)
+		})
+	})
+}
+
+func TestLoadStrict(t *testing.T) {
+	contents := map[string]string{
+		"config.toml": "[postgres]\npasword = \"password\"\n",
+		"config.yml":  "postgres:\n  pasword: password\n",
+		"config.json": `{"postgres": {"pasword": "password"}}`,
+		"config.xml":  "<config><postgres><pasword>password</pasword></postgres></config>",
+	}
+
+	t.Run("should return an error for unknown keys", func(t *testing.T) {
+		for fileName, content := range contents {
+			filePath := filepath.Join(t.TempDir(), fileName)
+			err := os.WriteFile(filePath, []byte(content), 0o600)
+			require.NoError(t, err)
+
+			cfg, err := LoadStrict(filePath)
+			assert.Equal(t, config.Config{}, cfg, fileName)
+			assert.ErrorIs(t, err, config.ErrUnknownKey, fileName)
+			assert.ErrorContains(t, err, "postgres.pasword", fileName)
+		}
+	})
+
+	t.Run("should return an error for unsupported formats", func(t *testing.T) {
+		_, err := LoadStrict("config.ini")
+		assert.Error(t, err)
+	})
+}
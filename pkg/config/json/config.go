@@ -1,65 +1,137 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/ribeirohugo/go_config/v2/pkg/config"
 )
 
+const tagName = "json"
+
 // Load loads configurations from a given json file path.
 func Load(filePath string) (config.Config, error) {
+	return load(filePath, decode)
+}
+
+// LoadStrict loads configurations from a given json file path, returning an error for unknown keys.
+func LoadStrict(filePath string) (config.Config, error) {
+	return load(filePath, decodeStrict)
+}
+
+// LoadContent loads configurations from a given json bytes content.
+func LoadContent(content []byte) (config.Config, error) {
+	return decode("", content)
+}
+
+// LoadContentStrict loads configurations from a given json bytes content, returning an error for unknown keys.
+func LoadContentStrict(content []byte) (config.Config, error) {
+	return decodeStrict("", content)
+}
+
+func load(filePath string, decode func(string, []byte) (config.Config, error)) (config.Config, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return config.Config{}, err
+		return config.Config{}, &config.Error{Source: filePath, Err: err}
 	}
 
 	bytes, err := io.ReadAll(file)
 	if err != nil {
-		return config.Config{}, err
+		return config.Config{}, &config.Error{Source: filePath, Err: err}
 	}
 	_ = file.Close()
 
-	return LoadContent(bytes)
+	return decode(filePath, bytes)
 }
 
-// LoadContent loads configurations from a given json bytes content.
-func LoadContent(content []byte) (config.Config, error) {
-	cfg := config.Config{
-		MySql: config.Database{
-			Port:           config.DefaultMySQLPort,
-			MigrationsPath: config.DefaultMigrationsMysql,
-		},
-		MongoDb: config.Database{
-			Port:           config.DefaultMongoPort,
-			MigrationsPath: config.DefaultMigrationsMongo,
-		},
-		Postgres: config.Database{
-			Port:           config.DefaultPostgresPort,
-			MigrationsPath: config.DefaultMigrationsPostgres,
-		},
-		Token: config.Token{
-			MaxAge: config.DefaultSessionMaxAge,
-		},
-		Loki: config.ExternalService{
-			Host: config.DefaultLokiHost,
-		},
-		Tempo: config.ExternalService{
-			Host: config.DefaultTempoHost,
-		},
-		Jaeger: config.ExternalService{
-			Host: config.DefaultJaegerHost,
-		},
-		Redis: config.ExternalService{
-			Host: config.DefaultRedisHost,
-		},
-	}
+func decode(source string, content []byte) (config.Config, error) {
+	cfg := config.Default()
 
 	err := json.Unmarshal(content, &cfg)
 	if err != nil {
-		return config.Config{}, err
+		return config.Config{}, decodeError(source, content, err)
+	}
+
+	return cfg.ApplyURLs(source)
+}
+
+func decodeStrict(source string, content []byte) (config.Config, error) {
+	var values any
+
+	err := json.Unmarshal(content, &values)
+	if err != nil {
+		return config.Config{}, decodeError(source, content, err)
+	}
+
+	var errs []error
+	for _, key := range findUnknownKeys(values, nil) {
+		errs = append(errs, &config.Error{Source: source, Key: key, Err: config.ErrUnknownKey})
+	}
+	if len(errs) > 0 {
+		return config.Config{}, errors.Join(errs...)
+	}
+
+	return decode(source, content)
+}
+
+// decodeError wraps a json error into a config.Error, with the error line, column and key when available.
+func decodeError(source string, content []byte, err error) error {
+	cfgErr := &config.Error{Source: source, Err: err}
+
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &syntaxErr):
+		cfgErr.Line, cfgErr.Column = position(content, syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		cfgErr.Key = typeErr.Field
+		cfgErr.Line, cfgErr.Column = position(content, typeErr.Offset)
+	}
+
+	return cfgErr
+}
+
+// position returns the line and column of the last byte read before a given offset, both starting at 1.
+func position(content []byte, offset int64) (int, int) {
+	if offset <= 0 || offset > int64(len(content)) {
+		return 0, 0
+	}
+
+	read := content[:offset]
+	line := bytes.Count(read, []byte("\n")) + 1
+	column := len(read) - bytes.LastIndexByte(read, '\n') - 1
+
+	return line, column
+}
+
+// findUnknownKeys walks decoded objects and returns the paths of keys not matching any Config field.
+// Keys match field names case-insensitively, as they do when decoding.
+func findUnknownKeys(value any, path []string) []string {
+	var unknownKeys []string
+
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(typedValue)) {
+			keyPath := append(path[:len(path):len(path)], key)
+			if !config.KnownKeyFold(tagName, keyPath...) {
+				unknownKeys = append(unknownKeys, strings.Join(keyPath, "."))
+				continue
+			}
+			unknownKeys = append(unknownKeys, findUnknownKeys(typedValue[key], keyPath)...)
+		}
+	case []any:
+		for _, item := range typedValue {
+			unknownKeys = append(unknownKeys, findUnknownKeys(item, path)...)
+		}
 	}
 
-	return cfg, nil
+	return unknownKeys
 }
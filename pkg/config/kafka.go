@@ -0,0 +1,42 @@
+package config
+
+import (
+	"crypto/tls"
+	"slices"
+)
+
+// Kafka SASL mechanisms.
+const (
+	SASLPlain       = "PLAIN"
+	SASLScramSHA256 = "SCRAM-SHA-256"
+	SASLScramSHA512 = "SCRAM-SHA-512"
+)
+
+var saslMechanisms = []string{SASLPlain, SASLScramSHA256, SASLScramSHA512}
+
+// GetBrokers returns the Kafka broker addresses, defaulting to DefaultKafkaBroker when none is set.
+func (k Kafka) GetBrokers() []string {
+	if len(k.Brokers) == 0 {
+		return []string{DefaultKafkaBroker}
+	}
+	return k.Brokers
+}
+
+// SASLEnabled reports whether SASL authentication is configured.
+func (k Kafka) SASLEnabled() bool {
+	return k.SASL.Username != ""
+}
+
+// TLSConfig returns a TLS configuration verifying brokers with TLSCAFile, when set,
+// and holding the client certificate, when set. It returns nil when TLS is not enabled.
+func (k Kafka) TLSConfig() (*tls.Config, error) {
+	if !k.TLS {
+		return nil, nil
+	}
+
+	return clientTLSConfig(k.TLSCAFile, k.TLSCertFile, k.TLSKeyFile)
+}
+
+func validSASLMechanism(mechanism string) bool {
+	return slices.Contains(saslMechanisms, mechanism)
+}
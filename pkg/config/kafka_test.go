@@ -0,0 +1,61 @@
+package config
+
+import (
+	"crypto/tls"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafka_GetBrokers(t *testing.T) {
+	t.Run("should return the configured brokers", func(t *testing.T) {
+		kafka := Kafka{Brokers: []string{"kafka1.domain:9092", "kafka2.domain:9092"}}
+		assert.Equal(t, []string{"kafka1.domain:9092", "kafka2.domain:9092"}, kafka.GetBrokers())
+	})
+
+	t.Run("should default to a local broker", func(t *testing.T) {
+		assert.Equal(t, []string{DefaultKafkaBroker}, Kafka{}.GetBrokers())
+	})
+}
+
+func TestKafka_SASLEnabled(t *testing.T) {
+	assert.False(t, Kafka{SASL: SASL{Mechanism: SASLPlain}}.SASLEnabled())
+	assert.True(t, Kafka{SASL: SASL{Mechanism: SASLScramSHA256, Username: "kafka.user"}}.SASLEnabled())
+}
+
+func TestKafka_TLSConfig(t *testing.T) {
+	t.Run("should return nil when TLS is disabled", func(t *testing.T) {
+		tlsConfig, err := Kafka{TLSCAFile: "ca.crt"}.TLSConfig()
+		require.NoError(t, err)
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("should use the system pool without CA file", func(t *testing.T) {
+		tlsConfig, err := Kafka{TLS: true}.TLSConfig()
+		require.NoError(t, err)
+		assert.Nil(t, tlsConfig.RootCAs)
+		assert.Empty(t, tlsConfig.Certificates)
+		assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+	})
+
+	t.Run("should load the CA and client certificates", func(t *testing.T) {
+		certFile, keyFile := createCertificate(t)
+
+		tlsConfig, err := Kafka{TLS: true, TLSCAFile: certFile, TLSCertFile: certFile, TLSKeyFile: keyFile}.TLSConfig()
+		require.NoError(t, err)
+		assert.NotNil(t, tlsConfig.RootCAs)
+		assert.Len(t, tlsConfig.Certificates, 1)
+	})
+
+	t.Run("should return an error for invalid CA files", func(t *testing.T) {
+		_, keyFile := createCertificate(t)
+
+		_, err := Kafka{TLS: true, TLSCAFile: filepath.Join(t.TempDir(), "missing.crt")}.TLSConfig()
+		assert.ErrorContains(t, err, "loading TLS CA certificate")
+
+		_, err = Kafka{TLS: true, TLSCAFile: keyFile}.TLSConfig()
+		assert.EqualError(t, err, "loading TLS CA certificate: no certificate found")
+	})
+}
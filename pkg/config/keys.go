@@ -0,0 +1,67 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ErrUnknownKey is returned by strict loaders for keys that don't match any Config field.
+var ErrUnknownKey = errors.New("unknown config key")
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// KnownKey reports whether a key path, like "postgres", "host", matches a Config field.
+// Field names are read from a given struct tag, like toml or yaml.
+// Keys nested in maps, like settings entries, are always known.
+func KnownKey(tag string, path ...string) bool {
+	return knownKey(tag, false, path)
+}
+
+// KnownKeyFold is like KnownKey, but matches field names case-insensitively, as encoding/json does.
+func KnownKeyFold(tag string, path ...string) bool {
+	return knownKey(tag, true, path)
+}
+
+func knownKey(tag string, fold bool, path []string) bool {
+	fieldType := reflect.TypeFor[Config]()
+
+	for _, key := range path {
+		for fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if reflect.PointerTo(fieldType).Implements(textUnmarshalerType) {
+			return false
+		}
+
+		switch fieldType.Kind() {
+		case reflect.Map:
+			fieldType = fieldType.Elem()
+		case reflect.Struct:
+			field, ok := fieldByTag(fieldType, tag, key, fold)
+			if !ok {
+				return false
+			}
+			fieldType = field.Type
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func fieldByTag(structType reflect.Type, tag string, key string, fold bool) (reflect.StructField, bool) {
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+		if name == key || (fold && strings.EqualFold(name, key)) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
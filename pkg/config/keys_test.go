@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnownKey(t *testing.T) {
+	t.Run("should return true for config fields", func(t *testing.T) {
+		assert.True(t, KnownKey("toml", "environment"))
+		assert.True(t, KnownKey("toml", "postgres"))
+		assert.True(t, KnownKey("yaml", "postgres", "migrations_path"))
+		assert.True(t, KnownKey("json", "server", "allowed_origins"))
+	})
+
+	t.Run("should return true for map entries", func(t *testing.T) {
+		assert.True(t, KnownKey("toml", "settings", "any_setting"))
+		assert.True(t, KnownKey("xml", "databases", "analytics", "driver"))
+		assert.False(t, KnownKey("xml", "databases", "analytics", "hots"))
+	})
+
+	t.Run("should return false for unknown fields", func(t *testing.T) {
+		assert.False(t, KnownKey("toml", "tracer"))
+		assert.False(t, KnownKey("toml", "postgres", "pasword"))
+		assert.False(t, KnownKey("toml", "server", "host", "name"))
+		assert.False(t, KnownKey("toml", "settings", "any_setting", "name"))
+	})
+
+	t.Run("should read field names from the given tag", func(t *testing.T) {
+		assert.False(t, KnownKey("xml", "settings"))
+		assert.True(t, KnownKey("toml", "settings"))
+	})
+
+	t.Run("should match field names case-insensitively with fold", func(t *testing.T) {
+		assert.True(t, KnownKeyFold("json", "Server", "HOST"))
+		assert.False(t, KnownKey("json", "Server", "HOST"))
+		assert.False(t, KnownKeyFold("json", "server", "hots"))
+	})
+}
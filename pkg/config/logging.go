@@ -0,0 +1,181 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Log formats.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// Log outputs, other than file paths.
+const (
+	LogOutputStdout = "stdout"
+	LogOutputStderr = "stderr"
+)
+
+// Log record attributes set from Config.
+const (
+	LogServiceKey     = "service"
+	LogEnvironmentKey = "environment"
+)
+
+// Logger returns a logger writing to Log.Output, tagging every record with Service and Environment, when set.
+// The returned closer closes the output file, and should be called once the logger is no longer used.
+func (c Config) Logger() (*slog.Logger, io.Closer, error) {
+	writer, err := c.Log.Writer()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger, err := c.NewLogger(writer)
+	if err != nil {
+		_ = writer.Close()
+		return nil, nil, err
+	}
+
+	return logger, writer, nil
+}
+
+// NewLogger returns a logger writing to w, tagging every record with Service and Environment, when set.
+func (c Config) NewLogger(w io.Writer) (*slog.Logger, error) {
+	handler, err := c.Log.Handler(w)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := slog.New(handler)
+	if c.Service != "" {
+		logger = logger.With(slog.String(LogServiceKey, c.Service))
+	}
+	if c.Environment != "" {
+		logger = logger.With(slog.String(LogEnvironmentKey, c.Environment))
+	}
+
+	return logger, nil
+}
+
+// Handler returns a text or json handler writing to w.
+// Records below the level of the package logging them, as set in Levels, are discarded.
+func (l Logging) Handler(w io.Writer) (slog.Handler, error) {
+	level, err := parseLogLevel(l.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	minLevel := level
+	levels := make(map[string]slog.Level, len(l.Levels))
+	for pkg, value := range l.Levels {
+		levels[pkg], err = parseLogLevel(value)
+		if err != nil {
+			return nil, fmt.Errorf("package %s: %w", pkg, err)
+		}
+		minLevel = min(minLevel, levels[pkg])
+	}
+
+	options := &slog.HandlerOptions{AddSource: l.Source, Level: minLevel}
+	var handler slog.Handler
+	switch l.Format {
+	case "", LogFormatText:
+		handler = slog.NewTextHandler(w, options)
+	case LogFormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unsupported log format %q, must be text or json", l.Format)
+	}
+
+	if len(levels) == 0 {
+		return handler, nil
+	}
+	return &packageHandler{Handler: handler, level: level, levels: levels}, nil
+}
+
+// Writer opens Output: the standard output, the standard error or a file, created if needed and appended to.
+// Closing the standard output or error writer does nothing.
+func (l Logging) Writer() (io.WriteCloser, error) {
+	switch l.Output {
+	case "", LogOutputStdout:
+		return nopCloser{os.Stdout}, nil
+	case LogOutputStderr:
+		return nopCloser{os.Stderr}, nil
+	default:
+		return os.OpenFile(l.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	}
+}
+
+// parseLogLevel parses a level name, as accepted by slog.Level, defaulting to info.
+func parseLogLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if value == "" {
+		return level, nil
+	}
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return level, fmt.Errorf("invalid log level %q, must be debug, info, warn or error", value)
+	}
+	return level, nil
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// packageHandler discards records below the level of the package that logged them.
+// Packages match their import path or any parent path, the longest one taking precedence.
+type packageHandler struct {
+	slog.Handler
+
+	level  slog.Level
+	levels map[string]slog.Level
+}
+
+func (h *packageHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level < h.recordLevel(record.PC) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *packageHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &packageHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level, levels: h.levels}
+}
+
+func (h *packageHandler) WithGroup(name string) slog.Handler {
+	return &packageHandler{Handler: h.Handler.WithGroup(name), level: h.level, levels: h.levels}
+}
+
+func (h *packageHandler) recordLevel(pc uintptr) slog.Level {
+	if pc == 0 {
+		return h.level
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	pkg := functionPackage(frame.Function)
+
+	level, matched := h.level, ""
+	for path, pathLevel := range h.levels {
+		if (pkg == path || strings.HasPrefix(pkg, path+"/")) && len(path) > len(matched) {
+			level, matched = pathLevel, path
+		}
+	}
+	return level
+}
+
+// functionPackage returns the import path of a function name, as "example.com/app/db" of "example.com/app/db.(*DB).Open".
+func functionPackage(function string) string {
+	lastSlash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[lastSlash+1:], "."); dot >= 0 {
+		return function[:lastSlash+1+dot]
+	}
+	return function
+}
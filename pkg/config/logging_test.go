@@ -0,0 +1,145 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_NewLogger(t *testing.T) {
+	t.Run("should tag records with service and environment", func(t *testing.T) {
+		cfg := Config{
+			Service:     "orders",
+			Environment: "prod",
+			Log:         Logging{Format: LogFormatJSON},
+		}
+
+		var buf bytes.Buffer
+		logger, err := cfg.NewLogger(&buf)
+		require.NoError(t, err)
+		logger.Info("started", "port", 8080)
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, "INFO", record["level"])
+		assert.Equal(t, "started", record["msg"])
+		assert.Equal(t, "orders", record[LogServiceKey])
+		assert.Equal(t, "prod", record[LogEnvironmentKey])
+		assert.InDelta(t, 8080, record["port"], 0)
+	})
+
+	t.Run("should discard records below the level", func(t *testing.T) {
+		cfg := Config{Log: Logging{Level: "warn"}}
+
+		var buf bytes.Buffer
+		logger, err := cfg.NewLogger(&buf)
+		require.NoError(t, err)
+		logger.Info("ignored")
+		logger.Warn("kept")
+
+		assert.NotContains(t, buf.String(), "ignored")
+		assert.Contains(t, buf.String(), "level=WARN msg=kept")
+	})
+
+	t.Run("should add the source location", func(t *testing.T) {
+		cfg := Config{Log: Logging{Source: true}}
+
+		var buf bytes.Buffer
+		logger, err := cfg.NewLogger(&buf)
+		require.NoError(t, err)
+		logger.Info("started")
+
+		assert.Contains(t, buf.String(), "logging_test.go:")
+	})
+
+	t.Run("should return an error for invalid values", func(t *testing.T) {
+		_, err := Config{Log: Logging{Level: "verbose"}}.NewLogger(&bytes.Buffer{})
+		assert.EqualError(t, err, `invalid log level "verbose", must be debug, info, warn or error`)
+
+		_, err = Config{Log: Logging{Format: "logfmt"}}.NewLogger(&bytes.Buffer{})
+		assert.EqualError(t, err, `unsupported log format "logfmt", must be text or json`)
+	})
+}
+
+func TestLogging_Handler(t *testing.T) {
+	t.Run("should apply the level of the longest matching package", func(t *testing.T) {
+		logging := Logging{
+			Level: "debug",
+			Levels: Attributes{
+				"github.com/ribeirohugo/go_config":               "error",
+				"github.com/ribeirohugo/go_config/v2/pkg/config": "warn",
+			},
+		}
+
+		var buf bytes.Buffer
+		handler, err := logging.Handler(&buf)
+		require.NoError(t, err)
+		logger := slog.New(handler).With("request", 1)
+		logger.Info("ignored")
+		logger.Warn("kept")
+
+		assert.NotContains(t, buf.String(), "ignored")
+		assert.Contains(t, buf.String(), "msg=kept request=1")
+	})
+
+	t.Run("should apply the level to other packages", func(t *testing.T) {
+		logging := Logging{
+			Level:  "error",
+			Levels: Attributes{"example.com/app": "debug"},
+		}
+
+		var buf bytes.Buffer
+		handler, err := logging.Handler(&buf)
+		require.NoError(t, err)
+		slog.New(handler).Warn("ignored")
+
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestLogging_Writer(t *testing.T) {
+	t.Run("should write to the standard output", func(t *testing.T) {
+		writer, err := Logging{}.Writer()
+		require.NoError(t, err)
+		assert.Equal(t, nopCloser{os.Stdout}, writer)
+		assert.NoError(t, writer.Close())
+	})
+
+	t.Run("should append to a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+		require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+
+		cfg := Config{Log: Logging{Output: path}}
+		logger, closer, err := cfg.Logger()
+		require.NoError(t, err)
+		logger.Info("second")
+		require.NoError(t, closer.Close())
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "first\n")
+		assert.Contains(t, string(content), "msg=second")
+	})
+}
+
+func TestFunctionPackage(t *testing.T) {
+	tests := map[string]string{
+		"main.main":                                      "main",
+		"example.com/app/db.Open":                        "example.com/app/db",
+		"example.com/app/db.(*DB).Close":                 "example.com/app/db",
+		"example.com/app.v2/db.Open.func1":               "example.com/app.v2/db",
+		"github.com/ribeirohugo/go_config/v2/pkg/config": "github.com/ribeirohugo/go_config/v2/pkg/config",
+	}
+
+	for function, expected := range tests {
+		t.Run(function, func(t *testing.T) {
+			assert.Equal(t, expected, functionPackage(function))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"maps"
+	"net/http"
+)
+
+// LokiTenantHeader is the header of the tenant in multi-tenant Loki.
+const LokiTenantHeader = "X-Scope-OrgID"
+
+// Headers returns the headers of push requests, with the tenant and the bearer token, when set.
+func (l Loki) Headers() http.Header {
+	headers := make(http.Header, 2)
+	if l.TenantID != "" {
+		headers.Set(LokiTenantHeader, l.TenantID)
+	}
+	if l.Token != "" {
+		headers.Set("Authorization", "Bearer "+l.Token)
+	}
+	return headers
+}
+
+// LokiLabels returns the static labels of pushed streams, setting service from the service identifier
+// and environment from the environment, unless given in Loki.Labels.
+func (c Config) LokiLabels() Attributes {
+	labels := make(Attributes, len(c.Loki.Labels)+2)
+	if c.Service != "" {
+		labels[LogServiceKey] = c.Service
+	}
+	if c.Environment != "" {
+		labels[LogEnvironmentKey] = c.Environment
+	}
+	maps.Copy(labels, c.Loki.Labels)
+
+	return labels
+}
+
+// validLokiLabel reports whether a label name matches [a-zA-Z_][a-zA-Z0-9_]*, as required by Loki.
+func validLokiLabel(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case '0' <= r && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoki_Headers(t *testing.T) {
+	t.Run("should set the tenant and token headers", func(t *testing.T) {
+		loki := Loki{TenantID: "shop", Token: "loki.token"}
+		assert.Equal(t, http.Header{
+			"X-Scope-Orgid": []string{"shop"},
+			"Authorization": []string{"Bearer loki.token"},
+		}, loki.Headers())
+		assert.Equal(t, "shop", loki.Headers().Get(LokiTenantHeader))
+	})
+
+	t.Run("should return no headers without tenant and token", func(t *testing.T) {
+		assert.Empty(t, Loki{}.Headers())
+	})
+}
+
+func TestConfig_LokiLabels(t *testing.T) {
+	t.Run("should set service and environment labels", func(t *testing.T) {
+		cfg := Config{
+			Service:     "orders",
+			Environment: "prod",
+			Loki:        Loki{Labels: Attributes{"team": "payments"}},
+		}
+
+		assert.Equal(t, Attributes{
+			LogServiceKey:     "orders",
+			LogEnvironmentKey: "prod",
+			"team":            "payments",
+		}, cfg.LokiLabels())
+	})
+
+	t.Run("should prefer static labels", func(t *testing.T) {
+		cfg := Config{
+			Service: "orders",
+			Loki:    Loki{Labels: Attributes{LogServiceKey: "orders-worker"}},
+		}
+
+		assert.Equal(t, Attributes{LogServiceKey: "orders-worker"}, cfg.LokiLabels())
+	})
+}
+
+func TestValidLokiLabel(t *testing.T) {
+	tests := map[string]bool{
+		"team":         true,
+		"_internal":    true,
+		"region_2":     true,
+		"":             false,
+		"2region":      false,
+		"service.name": false,
+		"team-name":    false,
+	}
+
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, validLokiLabel(name))
+		})
+	}
+}
@@ -3,28 +3,43 @@ package config
 import (
 	"encoding/xml"
 	"fmt"
+	"time"
 )
 
 // Config holds configurations data and methods.
 type Config struct {
-	Server Server `toml:"server" yaml:"server" json:"server,omitempty" xml:"server"`
-	Token  Token  `toml:"token" yaml:"token" json:"token,omitempty" xml:"token"`
+	Server Server `toml:"server" yaml:"server" json:"server,omitempty" xml:"server" desc:"HTTP server configuration." required:"true"` //nolint:lll
+	Token  Token  `toml:"token" yaml:"token" json:"token,omitempty" xml:"token" desc:"Token configuration."`
 
-	MongoDb  Database `toml:"mongodb" yaml:"mongodb" json:"mongodb,omitempty" xml:"mongodb"`
-	MySql    Database `toml:"mysql" yaml:"mysql" json:"mysql,omitempty" xml:"mysql"` //nolint:revive
-	Postgres Database `toml:"postgres" yaml:"postgres" json:"postgres,omitempty" xml:"postgres"`
+	MongoDb  Database `toml:"mongodb" yaml:"mongodb" json:"mongodb,omitempty" xml:"mongodb" driver:"mongodb" desc:"MongoDB database configuration."`         //nolint:lll
+	MySql    Database `toml:"mysql" yaml:"mysql" json:"mysql,omitempty" xml:"mysql" driver:"mysql" desc:"MySQL database configuration."`                     //nolint:revive,lll
+	Postgres Database `toml:"postgres" yaml:"postgres" json:"postgres,omitempty" xml:"postgres" driver:"postgres" desc:"PostgreSQL database configuration."` //nolint:lll
 
-	Audit      ExternalService `toml:"audit" yaml:"audit" json:"audit,omitempty" xml:"audit"`
-	Jaeger     ExternalService `toml:"jaeger" yaml:"jaeger" json:"jaeger,omitempty" xml:"jaeger"`
-	Loki       ExternalService `toml:"loki" yaml:"loki" json:"loki,omitempty" xml:"loki"`
-	Tempo      ExternalService `toml:"tempo" yaml:"tempo" json:"tempo,omitempty" xml:"tempo"`
-	Prometheus ExternalService `toml:"prometheus" yaml:"prometheus" json:"prometheus,omitempty" xml:"prometheus"`
-	Redis      ExternalService `toml:"redis" yaml:"redis" json:"redis,omitempty" xml:"redis"`
+	SQLite     Database `toml:"sqlite" yaml:"sqlite" json:"sqlite,omitempty" xml:"sqlite" driver:"sqlite" desc:"SQLite database configuration."`                              //nolint:lll
+	SQLServer  Database `toml:"sqlserver" yaml:"sqlserver" json:"sqlserver,omitempty" xml:"sqlserver" driver:"sqlserver" desc:"Microsoft SQL Server database configuration."` //nolint:lll
+	ClickHouse Database `toml:"clickhouse" yaml:"clickhouse" json:"clickhouse,omitempty" xml:"clickhouse" driver:"clickhouse" desc:"ClickHouse database configuration."`      //nolint:lll
 
-	Environment string `toml:"environment" yaml:"environment" json:"environment,omitempty" xml:"environment"`
-	Service     string `toml:"service" yaml:"service" json:"service,omitempty" xml:"service"`
+	Databases Databases `toml:"databases" yaml:"databases" json:"databases,omitempty" xml:"databases" desc:"Named databases, each one with its driver."` //nolint:lll
 
-	Settings map[string]string `toml:"settings" yaml:"settings" json:"settings,omitempty"`
+	Audit      ExternalService `toml:"audit" yaml:"audit" json:"audit,omitempty" xml:"audit" desc:"Auditing service configuration."`                                  //nolint:lll
+	Jaeger     ExternalService `toml:"jaeger" yaml:"jaeger" json:"jaeger,omitempty" xml:"jaeger" desc:"Deprecated: use otel instead."`                                //nolint:lll
+	Loki       Loki            `toml:"loki" yaml:"loki" json:"loki,omitempty" xml:"loki" desc:"Grafana Loki logging service configuration."`                          //nolint:lll
+	Tempo      ExternalService `toml:"tempo" yaml:"tempo" json:"tempo,omitempty" xml:"tempo" desc:"Deprecated: use otel instead."`                                    //nolint:lll
+	Prometheus Prometheus      `toml:"prometheus" yaml:"prometheus" json:"prometheus,omitempty" xml:"prometheus" desc:"Prometheus monitoring service configuration."` //nolint:lll
+	Redis      Redis           `toml:"redis" yaml:"redis" json:"redis,omitempty" xml:"redis" desc:"Redis cache configuration."`
+	OTel       OTel            `toml:"otel" yaml:"otel" json:"otel,omitempty" xml:"otel" desc:"OpenTelemetry traces, metrics and logs exporters."` //nolint:lll
+	Log        Logging         `toml:"log" yaml:"log" json:"log,omitempty" xml:"log" desc:"Application logging configuration."`
+
+	Services Services `toml:"services" yaml:"services" json:"services,omitempty" xml:"services" desc:"Named external services, as payments."` //nolint:lll
+
+	Kafka    Kafka    `toml:"kafka" yaml:"kafka" json:"kafka,omitempty" xml:"kafka" desc:"Kafka message broker configuration."`
+	NATS     NATS     `toml:"nats" yaml:"nats" json:"nats,omitempty" xml:"nats" desc:"NATS message broker configuration."`
+	RabbitMQ RabbitMQ `toml:"rabbitmq" yaml:"rabbitmq" json:"rabbitmq,omitempty" xml:"rabbitmq" desc:"RabbitMQ message broker configuration."` //nolint:lll
+
+	Environment string `toml:"environment" yaml:"environment" json:"environment,omitempty" xml:"environment" desc:"Application environment, like dev or prod."` //nolint:lll
+	Service     string `toml:"service" yaml:"service" json:"service,omitempty" xml:"service" desc:"Application service identifier."`                            //nolint:lll
+
+	Settings map[string]string `toml:"settings" yaml:"settings" json:"settings,omitempty" xml:"-" desc:"Custom key-value settings."` //nolint:lll
 }
 
 // XML holds configurations data and methods, with XML support.
@@ -36,32 +51,236 @@ type XML struct {
 
 // Database holds database connection configurations.
 type Database struct {
-	Host           string `toml:"host" yaml:"host" json:"host,omitempty" xml:"host"`
-	Port           int    `toml:"port" yaml:"port" json:"port,omitempty" xml:"port"`
-	User           string `toml:"user" yaml:"user" json:"user,omitempty" xml:"user"`
-	Password       string `toml:"password" yaml:"password" json:"password,omitempty" xml:"password"`
-	Db             string `toml:"database" yaml:"database" json:"database,omitempty" xml:"database"`
-	MigrationsPath string `toml:"migrations_path" yaml:"migrations_path" json:"migrations_path,omitempty" xml:"migrations_path"`
+	Host           string `toml:"host" yaml:"host" json:"host,omitempty" xml:"host" desc:"Database host."`
+	Port           int    `toml:"port" yaml:"port" json:"port,omitempty" xml:"port" desc:"Database port."`
+	Socket         string `toml:"socket" yaml:"socket" json:"socket,omitempty" xml:"socket" desc:"Unix socket path of MySQL and Postgres, used instead of host and port."` //nolint:lll
+	User           string `toml:"user" yaml:"user" json:"user,omitempty" xml:"user" desc:"Database user with needed privileges over database."`                            //nolint:lll
+	Password       string `toml:"password" yaml:"password" json:"password,omitempty" xml:"password" desc:"Database password."`
+	Db             string `toml:"database" yaml:"database" json:"database,omitempty" xml:"database" desc:"Database name."`
+	MigrationsPath string `toml:"migrations_path" yaml:"migrations_path" json:"migrations_path,omitempty" xml:"migrations_path" desc:"Migrations directory path."`  //nolint:lll
+	Driver         string `toml:"driver" yaml:"driver" json:"driver,omitempty" xml:"driver" desc:"Database driver of named databases: mongodb, mysql or postgres."` //nolint:lll
+	URL            string `toml:"url" yaml:"url" json:"url,omitempty" xml:"url" desc:"Connection URL or MySQL DSN, taking precedence over connection fields."`      //nolint:lll
+
+	MaxOpenConns    int      `toml:"max_open_conns" yaml:"max_open_conns" json:"max_open_conns,omitempty" xml:"max_open_conns" desc:"Maximum number of open connections, or 0 for unlimited."`          //nolint:lll
+	MaxIdleConns    int      `toml:"max_idle_conns" yaml:"max_idle_conns" json:"max_idle_conns,omitempty" xml:"max_idle_conns" desc:"Maximum number of idle connections, or 0 for the driver default."` //nolint:lll
+	ConnMaxLifetime Duration `toml:"conn_max_lifetime" yaml:"conn_max_lifetime" json:"conn_max_lifetime,omitempty" xml:"conn_max_lifetime" desc:"Maximum duration a connection may be reused."`         //nolint:lll
+	ConnMaxIdleTime Duration `toml:"conn_max_idle_time" yaml:"conn_max_idle_time" json:"conn_max_idle_time,omitempty" xml:"conn_max_idle_time" desc:"Maximum duration a connection may be idle."`       //nolint:lll
+
+	Replicas []Replica `toml:"replicas" yaml:"replicas" json:"replicas,omitempty" xml:"replicas" desc:"Read replicas of the database."`
+
+	Hosts      []string `toml:"hosts" yaml:"hosts" json:"hosts,omitempty" xml:"hosts" driver:"mongodb" desc:"MongoDB replica set members, as host:port, used instead of host and port."`    //nolint:lll
+	SRV        bool     `toml:"srv" yaml:"srv" json:"srv,omitempty" xml:"srv" driver:"mongodb" desc:"Enables mongodb+srv:// addresses, resolving MongoDB hosts from the host DNS records."` //nolint:lll
+	ReplicaSet string   `toml:"replica_set" yaml:"replica_set" json:"replica_set,omitempty" xml:"replica_set" driver:"mongodb" desc:"MongoDB replica set name."`                            //nolint:lll
+	Options    string   `toml:"options" yaml:"options" json:"options,omitempty" xml:"options" desc:"Extra connection options, as a query string like retryWrites=true&w=majority."`         //nolint:lll
+
+	Path    string   `toml:"path" yaml:"path" json:"path,omitempty" xml:"path" driver:"sqlite" desc:"SQLite database file path."`
+	Pragmas []string `toml:"pragmas" yaml:"pragmas" json:"pragmas,omitempty" xml:"pragmas" driver:"sqlite" desc:"SQLite pragmas set on each connection, as journal_mode=WAL."` //nolint:lll
+}
+
+// Replica holds a read replica address. Unset port and credentials are taken from the primary database.
+type Replica struct {
+	Host     string `toml:"host" yaml:"host" json:"host,omitempty" xml:"host" desc:"Replica host."`
+	Port     int    `toml:"port" yaml:"port" json:"port,omitempty" xml:"port" desc:"Replica port, defaulting to the primary database port."`                         //nolint:lll
+	User     string `toml:"user" yaml:"user" json:"user,omitempty" xml:"user" desc:"Replica user, defaulting to the primary database user."`                         //nolint:lll
+	Password string `toml:"password" yaml:"password" json:"password,omitempty" xml:"password" desc:"Replica password, defaulting to the primary database password."` //nolint:lll
 }
 
-// Server holds server host and port configurations.
+// Server holds HTTP server address, TLS and timeout configurations.
 type Server struct {
-	Host           string   `toml:"host" yaml:"host" json:"host,omitempty" xml:"host"`
-	Port           int      `toml:"port" yaml:"port" json:"port,omitempty" xml:"port"`
-	AllowedOrigins []string `toml:"allowed_origins" yaml:"allowed_origins" json:"allowed_origins,omitempty" xml:"allowed_origins"` //nolint:lll
+	Host           string   `toml:"host" yaml:"host" json:"host,omitempty" xml:"host" desc:"HTTP server host name." required:"true"`                                                                      //nolint:lll
+	Port           int      `toml:"port" yaml:"port" json:"port,omitempty" xml:"port" desc:"HTTP server port number." required:"true"`                                                                    //nolint:lll
+	AllowedOrigins []string `toml:"allowed_origins" yaml:"allowed_origins" json:"allowed_origins,omitempty" xml:"allowed_origins" desc:"Origins allowed by CORS, as https://*.example.com or * for any."` //nolint:lll
+	CORS           CORS     `toml:"cors" yaml:"cors" json:"cors,omitempty" xml:"cors" desc:"CORS policy applied to AllowedOrigins."`
+
+	TLSCertFile   string `toml:"tls_cert_file" yaml:"tls_cert_file" json:"tls_cert_file,omitempty" xml:"tls_cert_file" desc:"TLS certificate file path, set along with tls_key_file."` //nolint:lll
+	TLSKeyFile    string `toml:"tls_key_file" yaml:"tls_key_file" json:"tls_key_file,omitempty" xml:"tls_key_file" desc:"TLS private key file path, set along with tls_cert_file."`    //nolint:lll
+	TLSMinVersion string `toml:"tls_min_version" yaml:"tls_min_version" json:"tls_min_version,omitempty" xml:"tls_min_version" desc:"Minimum TLS version: 1.0, 1.1, 1.2 or 1.3."`      //nolint:lll
+
+	ReadTimeout       Duration `toml:"read_timeout" yaml:"read_timeout" json:"read_timeout,omitempty" xml:"read_timeout" desc:"Maximum duration for reading an entire request."`                           //nolint:lll
+	ReadHeaderTimeout Duration `toml:"read_header_timeout" yaml:"read_header_timeout" json:"read_header_timeout,omitempty" xml:"read_header_timeout" desc:"Maximum duration for reading request headers."` //nolint:lll
+	WriteTimeout      Duration `toml:"write_timeout" yaml:"write_timeout" json:"write_timeout,omitempty" xml:"write_timeout" desc:"Maximum duration before timing out response writes."`                   //nolint:lll
+	IdleTimeout       Duration `toml:"idle_timeout" yaml:"idle_timeout" json:"idle_timeout,omitempty" xml:"idle_timeout" desc:"Maximum duration to wait for the next keep-alive request."`                 //nolint:lll
+	ShutdownTimeout   Duration `toml:"shutdown_timeout" yaml:"shutdown_timeout" json:"shutdown_timeout,omitempty" xml:"shutdown_timeout" desc:"Maximum duration to wait for a graceful shutdown."`         //nolint:lll
+	MaxHeaderBytes    int      `toml:"max_header_bytes" yaml:"max_header_bytes" json:"max_header_bytes,omitempty" xml:"max_header_bytes" desc:"Maximum size of request headers, in bytes."`                //nolint:lll
+}
+
+// CORS holds cross-origin resource sharing policies for the allowed origins.
+type CORS struct {
+	AllowedMethods   []string `toml:"allowed_methods" yaml:"allowed_methods" json:"allowed_methods,omitempty" xml:"allowed_methods" desc:"Methods allowed in cross-origin requests, defaulting to GET, HEAD and POST."` //nolint:lll
+	AllowedHeaders   []string `toml:"allowed_headers" yaml:"allowed_headers" json:"allowed_headers,omitempty" xml:"allowed_headers" desc:"Request headers allowed in cross-origin requests, or * for any."`             //nolint:lll
+	ExposedHeaders   []string `toml:"exposed_headers" yaml:"exposed_headers" json:"exposed_headers,omitempty" xml:"exposed_headers" desc:"Response headers exposed to cross-origin requests."`                          //nolint:lll
+	AllowCredentials bool     `toml:"allow_credentials" yaml:"allow_credentials" json:"allow_credentials,omitempty" xml:"allow_credentials" desc:"Allows cookies and credentials in cross-origin requests."`            //nolint:lll
+	MaxAge           Duration `toml:"max_age" yaml:"max_age" json:"max_age,omitempty" xml:"max_age" desc:"Duration preflight responses can be cached."`                                                                 //nolint:lll
 }
 
-// Token holds application token secret and expire time in seconds.
+// Token holds application token signing algorithm, keys, claims and expire times.
+// HMAC algorithms sign with Secret, while asymmetric ones sign with the key of PrivateKeyFile.
 type Token struct {
-	MaxAge int    `toml:"max_age" yaml:"max_age" json:"max_age,omitempty" xml:"max_age"`
-	Secret string `toml:"secret" yaml:"secret" json:"secret,omitempty" xml:"secret"`
+	MaxAge           Duration   `toml:"max_age" yaml:"max_age" json:"max_age,omitempty" xml:"max_age" desc:"Maximum token age, as a duration like 24h or a number of seconds."`                                          //nolint:lll
+	Secret           string     `toml:"secret" yaml:"secret" json:"secret,omitempty" xml:"secret" desc:"Token signing secret of HMAC algorithms."`                                                                       //nolint:lll
+	Algorithm        string     `toml:"algorithm" yaml:"algorithm" json:"algorithm,omitempty" xml:"algorithm" desc:"Signing algorithm, as HS256, RS256, PS256, ES256 or EdDSA."`                                         //nolint:lll
+	Issuer           string     `toml:"issuer" yaml:"issuer" json:"issuer,omitempty" xml:"issuer" desc:"Issuer claim of signed tokens, required in verified tokens."`                                                    //nolint:lll
+	Audience         []string   `toml:"audience" yaml:"audience" json:"audience,omitempty" xml:"audience" desc:"Audience claim of signed tokens, one of them required in verified tokens."`                              //nolint:lll
+	RefreshMaxAge    Duration   `toml:"refresh_max_age" yaml:"refresh_max_age" json:"refresh_max_age,omitempty" xml:"refresh_max_age" desc:"Maximum refresh token age, as a duration like 168h or a number of seconds."` //nolint:lll
+	KeyID            string     `toml:"key_id" yaml:"key_id" json:"key_id,omitempty" xml:"key_id" desc:"Key identifier, set as kid header of signed tokens."`                                                            //nolint:lll
+	PrivateKeyFile   string     `toml:"private_key_file" yaml:"private_key_file" json:"private_key_file,omitempty" xml:"private_key_file" desc:"PEM private key file of asymmetric algorithms."`                         //nolint:lll
+	PublicKeyFile    string     `toml:"public_key_file" yaml:"public_key_file" json:"public_key_file,omitempty" xml:"public_key_file" desc:"PEM public key or certificate file, derived from the private key if unset."` //nolint:lll
+	VerificationKeys []TokenKey `toml:"verification_keys" yaml:"verification_keys" json:"verification_keys,omitempty" xml:"verification_keys" desc:"Previous keys, still accepted in verified tokens."`                  //nolint:lll
+}
+
+// TokenKey holds a previous token key, identified by the kid header of the tokens it signed.
+type TokenKey struct {
+	KeyID         string `toml:"key_id" yaml:"key_id" json:"key_id,omitempty" xml:"key_id" desc:"Key identifier."`
+	Secret        string `toml:"secret" yaml:"secret" json:"secret,omitempty" xml:"secret" desc:"Secret of HMAC algorithms."`
+	PublicKeyFile string `toml:"public_key_file" yaml:"public_key_file" json:"public_key_file,omitempty" xml:"public_key_file" desc:"PEM public key or certificate file of asymmetric algorithms."` //nolint:lll
+}
+
+// Redis holds Redis connection configurations, for a single node, Sentinel or Cluster deployments.
+type Redis struct {
+	Enabled  bool   `toml:"enabled" yaml:"enabled" json:"enabled,omitempty" xml:"enabled" desc:"Enables the service."`
+	Host     string `toml:"host" yaml:"host" json:"host,omitempty" xml:"host" desc:"Redis address, as host:port."`
+	Username string `toml:"username" yaml:"username" json:"username,omitempty" xml:"username" desc:"Redis ACL username."`
+	Password string `toml:"password" yaml:"password" json:"password,omitempty" xml:"password" desc:"Redis password."`
+	Token    string `toml:"token" yaml:"token" json:"token,omitempty" xml:"token" desc:"Deprecated: use password instead."`
+	DB       int    `toml:"db" yaml:"db" json:"db,omitempty" xml:"db" desc:"Redis database index."`
+	TLS      bool   `toml:"tls" yaml:"tls" json:"tls,omitempty" xml:"tls" desc:"Enables TLS connections."`
+
+	PoolSize     int      `toml:"pool_size" yaml:"pool_size" json:"pool_size,omitempty" xml:"pool_size" desc:"Maximum number of connections, or 0 for the client default."` //nolint:lll
+	DialTimeout  Duration `toml:"dial_timeout" yaml:"dial_timeout" json:"dial_timeout,omitempty" xml:"dial_timeout" desc:"Timeout for establishing new connections."`       //nolint:lll
+	ReadTimeout  Duration `toml:"read_timeout" yaml:"read_timeout" json:"read_timeout,omitempty" xml:"read_timeout" desc:"Timeout for reading command replies."`            //nolint:lll
+	WriteTimeout Duration `toml:"write_timeout" yaml:"write_timeout" json:"write_timeout,omitempty" xml:"write_timeout" desc:"Timeout for writing commands."`               //nolint:lll
+
+	MasterName        string   `toml:"master_name" yaml:"master_name" json:"master_name,omitempty" xml:"master_name" desc:"Sentinel master name."`                                         //nolint:lll
+	SentinelAddresses []string `toml:"sentinel_addresses" yaml:"sentinel_addresses" json:"sentinel_addresses,omitempty" xml:"sentinel_addresses" desc:"Sentinel addresses, as host:port."` //nolint:lll
+	ClusterAddresses  []string `toml:"cluster_addresses" yaml:"cluster_addresses" json:"cluster_addresses,omitempty" xml:"cluster_addresses" desc:"Cluster node addresses, as host:port."` //nolint:lll
+}
+
+// Kafka holds Kafka client configurations, with optional SASL authentication and TLS.
+type Kafka struct {
+	Enabled       bool     `toml:"enabled" yaml:"enabled" json:"enabled,omitempty" xml:"enabled" desc:"Enables the service."`
+	Brokers       []string `toml:"brokers" yaml:"brokers" json:"brokers,omitempty" xml:"brokers" desc:"Kafka broker addresses, as host:port."`                            //nolint:lll
+	ClientID      string   `toml:"client_id" yaml:"client_id" json:"client_id,omitempty" xml:"client_id" desc:"Client identifier sent to brokers."`                       //nolint:lll
+	ConsumerGroup string   `toml:"consumer_group" yaml:"consumer_group" json:"consumer_group,omitempty" xml:"consumer_group" desc:"Consumer group identifier."`           //nolint:lll
+	DialTimeout   Duration `toml:"dial_timeout" yaml:"dial_timeout" json:"dial_timeout,omitempty" xml:"dial_timeout" desc:"Timeout for establishing broker connections."` //nolint:lll
+	SASL          SASL     `toml:"sasl" yaml:"sasl" json:"sasl,omitempty" xml:"sasl" desc:"SASL authentication, enabled when a username is set."`                         //nolint:lll
+
+	TLS         bool   `toml:"tls" yaml:"tls" json:"tls,omitempty" xml:"tls" desc:"Enables TLS connections."`
+	TLSCAFile   string `toml:"tls_ca_file" yaml:"tls_ca_file" json:"tls_ca_file,omitempty" xml:"tls_ca_file" desc:"CA certificate file path verifying brokers, defaulting to the system pool."` //nolint:lll
+	TLSCertFile string `toml:"tls_cert_file" yaml:"tls_cert_file" json:"tls_cert_file,omitempty" xml:"tls_cert_file" desc:"Client certificate file path, set along with tls_key_file."`         //nolint:lll
+	TLSKeyFile  string `toml:"tls_key_file" yaml:"tls_key_file" json:"tls_key_file,omitempty" xml:"tls_key_file" desc:"Client private key file path, set along with tls_cert_file."`            //nolint:lll
+}
+
+// SASL holds SASL authentication credentials.
+type SASL struct {
+	Mechanism string `toml:"mechanism" yaml:"mechanism" json:"mechanism,omitempty" xml:"mechanism" desc:"SASL mechanism: PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512."` //nolint:lll
+	Username  string `toml:"username" yaml:"username" json:"username,omitempty" xml:"username" desc:"SASL username."`
+	Password  string `toml:"password" yaml:"password" json:"password,omitempty" xml:"password" desc:"SASL password."`
+}
+
+// NATS holds NATS client configurations.
+type NATS struct {
+	Enabled         bool     `toml:"enabled" yaml:"enabled" json:"enabled,omitempty" xml:"enabled" desc:"Enables the service."`
+	URLs            []string `toml:"urls" yaml:"urls" json:"urls,omitempty" xml:"urls" desc:"NATS server URLs, as nats://host:4222."` //nolint:lll
+	Name            string   `toml:"name" yaml:"name" json:"name,omitempty" xml:"name" desc:"Connection name sent to servers."`
+	CredentialsFile string   `toml:"credentials_file" yaml:"credentials_file" json:"credentials_file,omitempty" xml:"credentials_file" desc:"Credentials file path, holding a user JWT and NKey seed."` //nolint:lll
+	ConnectTimeout  Duration `toml:"connect_timeout" yaml:"connect_timeout" json:"connect_timeout,omitempty" xml:"connect_timeout" desc:"Timeout for establishing server connections."`                 //nolint:lll
+}
+
+// RabbitMQ holds RabbitMQ connection configurations.
+type RabbitMQ struct {
+	Enabled   bool     `toml:"enabled" yaml:"enabled" json:"enabled,omitempty" xml:"enabled" desc:"Enables the service."`
+	Host      string   `toml:"host" yaml:"host" json:"host,omitempty" xml:"host" desc:"RabbitMQ host."`
+	Port      int      `toml:"port" yaml:"port" json:"port,omitempty" xml:"port" desc:"RabbitMQ port."`
+	User      string   `toml:"user" yaml:"user" json:"user,omitempty" xml:"user" desc:"RabbitMQ user."`
+	Password  string   `toml:"password" yaml:"password" json:"password,omitempty" xml:"password" desc:"RabbitMQ password."`
+	VHost     string   `toml:"vhost" yaml:"vhost" json:"vhost,omitempty" xml:"vhost" desc:"RabbitMQ virtual host."`
+	TLS       bool     `toml:"tls" yaml:"tls" json:"tls,omitempty" xml:"tls" desc:"Enables TLS connections, using the amqps scheme."`                   //nolint:lll
+	Heartbeat Duration `toml:"heartbeat" yaml:"heartbeat" json:"heartbeat,omitempty" xml:"heartbeat" desc:"Interval of heartbeats sent to the server."` //nolint:lll
+}
+
+// OTel holds OpenTelemetry SDK configurations, with OTLP exporter values shared by traces, metrics and logs.
+type OTel struct {
+	Enabled            bool       `toml:"enabled" yaml:"enabled" json:"enabled,omitempty" xml:"enabled" desc:"Enables the OpenTelemetry SDK."`                                                                             //nolint:lll
+	ServiceName        string     `toml:"service_name" yaml:"service_name" json:"service_name,omitempty" xml:"service_name" desc:"Service name resource attribute, defaulting to the service identifier."`                 //nolint:lll
+	ResourceAttributes Attributes `toml:"resource_attributes" yaml:"resource_attributes" json:"resource_attributes,omitempty" xml:"resource_attributes" desc:"Resource attributes of every signal, as service.namespace."` //nolint:lll
+	SampleRatio        float64    `toml:"sample_ratio" yaml:"sample_ratio" json:"sample_ratio,omitempty" xml:"sample_ratio" desc:"Ratio of sampled traces, from 0 to 1."`                                                  //nolint:lll
+	MetricsInterval    Duration   `toml:"metrics_interval" yaml:"metrics_interval" json:"metrics_interval,omitempty" xml:"metrics_interval" desc:"Interval between metrics exports."`                                      //nolint:lll
+
+	Endpoint string     `toml:"endpoint" yaml:"endpoint" json:"endpoint,omitempty" xml:"endpoint" desc:"OTLP collector endpoint, as http://localhost:4318."`      //nolint:lll
+	Protocol string     `toml:"protocol" yaml:"protocol" json:"protocol,omitempty" xml:"protocol" desc:"OTLP protocol: grpc, http/protobuf or http/json."`        //nolint:lll
+	Headers  Attributes `toml:"headers" yaml:"headers" json:"headers,omitempty" xml:"headers" desc:"Headers sent with every export, like authentication tokens."` //nolint:lll
+	Insecure bool       `toml:"insecure" yaml:"insecure" json:"insecure,omitempty" xml:"insecure" desc:"Disables TLS of gRPC exports."`                           //nolint:lll
+	Timeout  Duration   `toml:"timeout" yaml:"timeout" json:"timeout,omitempty" xml:"timeout" desc:"Timeout of each export."`
+
+	Traces  OTelExporter `toml:"traces" yaml:"traces" json:"traces,omitempty" xml:"traces" desc:"Traces exporter."`
+	Metrics OTelExporter `toml:"metrics" yaml:"metrics" json:"metrics,omitempty" xml:"metrics" desc:"Metrics exporter."`
+	Logs    OTelExporter `toml:"logs" yaml:"logs" json:"logs,omitempty" xml:"logs" desc:"Logs exporter."`
+}
+
+// Loki holds Grafana Loki push configurations.
+type Loki struct {
+	Enabled  bool       `toml:"enabled" yaml:"enabled" json:"enabled,omitempty" xml:"enabled" desc:"Enables the service."`
+	Host     string     `toml:"host" yaml:"host" json:"host,omitempty" xml:"host" desc:"Loki push API address."`
+	Token    string     `toml:"token" yaml:"token" json:"token,omitempty" xml:"token" desc:"Bearer token of push requests."`
+	TenantID string     `toml:"tenant_id" yaml:"tenant_id" json:"tenant_id,omitempty" xml:"tenant_id" desc:"Tenant sent as X-Scope-OrgID, in multi-tenant Loki."`  //nolint:lll
+	Labels   Attributes `toml:"labels" yaml:"labels" json:"labels,omitempty" xml:"labels" desc:"Static labels of every stream, added to service and environment."` //nolint:lll
+
+	BatchSize int      `toml:"batch_size" yaml:"batch_size" json:"batch_size,omitempty" xml:"batch_size" desc:"Maximum batch size in bytes, before pushing."` //nolint:lll
+	BatchWait Duration `toml:"batch_wait" yaml:"batch_wait" json:"batch_wait,omitempty" xml:"batch_wait" desc:"Maximum time to wait before pushing a batch."` //nolint:lll
+	Timeout   Duration `toml:"timeout" yaml:"timeout" json:"timeout,omitempty" xml:"timeout" desc:"Timeout of each push request."`                            //nolint:lll
+}
+
+// Prometheus holds the configurations of the Prometheus metrics endpoint, scraped by Prometheus servers.
+type Prometheus struct {
+	Enabled bool   `toml:"enabled" yaml:"enabled" json:"enabled,omitempty" xml:"enabled" desc:"Enables the metrics endpoint."`
+	Host    string `toml:"host" yaml:"host" json:"host,omitempty" xml:"host" desc:"Listen host, or empty for every interface."`
+	Port    int    `toml:"port" yaml:"port" json:"port,omitempty" xml:"port" desc:"Listen port."`
+	Path    string `toml:"path" yaml:"path" json:"path,omitempty" xml:"path" desc:"Metrics endpoint path."`
+
+	Namespace string `toml:"namespace" yaml:"namespace" json:"namespace,omitempty" xml:"namespace" desc:"Namespace prefixed to metric names."`                      //nolint:lll
+	Subsystem string `toml:"subsystem" yaml:"subsystem" json:"subsystem,omitempty" xml:"subsystem" desc:"Subsystem prefixed to metric names, after the namespace."` //nolint:lll
+
+	BasicAuth      BasicAuth `toml:"basic_auth" yaml:"basic_auth" json:"basic_auth,omitempty" xml:"basic_auth" desc:"Basic authentication required to scrape metrics."`                     //nolint:lll
+	Token          string    `toml:"token" yaml:"token" json:"token,omitempty" xml:"token" desc:"Deprecated: bearer token to scrape metrics, use basic_auth instead."`                      //nolint:lll
+	PushgatewayURL string    `toml:"pushgateway_url" yaml:"pushgateway_url" json:"pushgateway_url,omitempty" xml:"pushgateway_url" desc:"Pushgateway URL, for batch jobs pushing metrics."` //nolint:lll
+}
+
+// BasicAuth holds HTTP basic authentication credentials. It is disabled without username.
+type BasicAuth struct {
+	Username string `toml:"username" yaml:"username" json:"username,omitempty" xml:"username" desc:"Basic authentication username."` //nolint:lll
+	Password string `toml:"password" yaml:"password" json:"password,omitempty" xml:"password" desc:"Basic authentication password."` //nolint:lll
+}
+
+// Logging holds application logging configurations, used to build a slog.Logger.
+type Logging struct {
+	Level  string     `toml:"level" yaml:"level" json:"level,omitempty" xml:"level" desc:"Minimum log level: debug, info, warn or error."` //nolint:lll
+	Format string     `toml:"format" yaml:"format" json:"format,omitempty" xml:"format" desc:"Log format: text or json."`
+	Output string     `toml:"output" yaml:"output" json:"output,omitempty" xml:"output" desc:"Log destination: stdout, stderr or a file path."`    //nolint:lll
+	Source bool       `toml:"source" yaml:"source" json:"source,omitempty" xml:"source" desc:"Adds the source file and line of each record."`      //nolint:lll
+	Levels Attributes `toml:"levels" yaml:"levels" json:"levels,omitempty" xml:"levels" desc:"Minimum log level of each package, by import path."` //nolint:lll
+}
+
+// OTelExporter holds the exporter of a single OpenTelemetry signal. Empty values are taken from OTel.
+type OTelExporter struct {
+	Exporter string     `toml:"exporter" yaml:"exporter" json:"exporter,omitempty" xml:"exporter" desc:"Exporter: otlp, console or none."`  //nolint:lll
+	Endpoint string     `toml:"endpoint" yaml:"endpoint" json:"endpoint,omitempty" xml:"endpoint" desc:"Signal OTLP endpoint, used as is."` //nolint:lll
+	Protocol string     `toml:"protocol" yaml:"protocol" json:"protocol,omitempty" xml:"protocol" desc:"Signal OTLP protocol."`
+	Headers  Attributes `toml:"headers" yaml:"headers" json:"headers,omitempty" xml:"headers" desc:"Signal headers, added to the shared ones."` //nolint:lll
 }
 
 // ExternalService holds essential external service configuration data.
 type ExternalService struct {
-	Enabled bool   `toml:"enabled" yaml:"enabled" json:"enabled,omitempty" xml:"enabled"`
-	Host    string `toml:"host" yaml:"host" json:"host,omitempty" xml:"host"`
-	Token   string `toml:"token" yaml:"token" json:"token,omitempty" xml:"token"`
+	Enabled bool   `toml:"enabled" yaml:"enabled" json:"enabled,omitempty" xml:"enabled" desc:"Enables the service."`
+	Host    string `toml:"host" yaml:"host" json:"host,omitempty" xml:"host" desc:"Service host address."`
+	Token   string `toml:"token" yaml:"token" json:"token,omitempty" xml:"token" desc:"Service authentication token."`
+
+	Timeout Duration   `toml:"timeout" yaml:"timeout" json:"timeout,omitempty" xml:"timeout" desc:"Timeout of each call, retries included."`                                  //nolint:lll
+	Retries int        `toml:"retries" yaml:"retries" json:"retries,omitempty" xml:"retries" desc:"Retries of calls failing with a network error or an unavailable service."` //nolint:lll
+	Headers Attributes `toml:"headers" yaml:"headers" json:"headers,omitempty" xml:"headers" desc:"Static headers sent with every call."`                                     //nolint:lll
+
+	TLSCAFile   string `toml:"tls_ca_file" yaml:"tls_ca_file" json:"tls_ca_file,omitempty" xml:"tls_ca_file" desc:"CA certificate file path, defaulting to the system pool."`           //nolint:lll
+	TLSCertFile string `toml:"tls_cert_file" yaml:"tls_cert_file" json:"tls_cert_file,omitempty" xml:"tls_cert_file" desc:"Client certificate file path, set along with tls_key_file."` //nolint:lll
+	TLSKeyFile  string `toml:"tls_key_file" yaml:"tls_key_file" json:"tls_key_file,omitempty" xml:"tls_key_file" desc:"Client private key file path, set along with tls_cert_file."`    //nolint:lll
 }
 
 // GetAddress returns website address.
@@ -69,20 +288,72 @@ func (s Server) GetAddress() string {
 	return fmt.Sprintf("%s:%d", s.Host, s.Port)
 }
 
+// TLSEnabled reports whether a TLS certificate and key are configured.
+func (s Server) TLSEnabled() bool {
+	return s.TLSCertFile != "" && s.TLSKeyFile != ""
+}
+
+// GetMaxAge returns the maximum token age.
+func (t Token) GetMaxAge() time.Duration {
+	return t.MaxAge.Duration()
+}
+
+// GetRefreshMaxAge returns the maximum refresh token age.
+func (t Token) GetRefreshMaxAge() time.Duration {
+	return t.RefreshMaxAge.Duration()
+}
+
 // MongodbAddress returns MongoDB connection address.
 func (c Config) MongodbAddress() string {
-	return fmt.Sprintf("mongodb://%s:%s@%s:%d/%s?authSource=admin&ssl=false",
-		c.MongoDb.User, c.MongoDb.Password, c.MongoDb.Host, c.MongoDb.Port, c.MongoDb.Db)
+	return c.MongoDb.MongodbAddress()
 }
 
 // MysqlAddress returns MySQL connection address.
 func (c Config) MysqlAddress() string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
-		c.MySql.User, c.MySql.Password, c.MySql.Host, c.MySql.Port, c.MySql.Db)
+	return c.MySql.MysqlAddress()
 }
 
 // PostgresAddress returns PostgreSQL connection address.
 func (c Config) PostgresAddress() string {
-	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
-		c.Postgres.User, c.Postgres.Password, c.Postgres.Host, c.Postgres.Port, c.Postgres.Db)
+	return c.Postgres.PostgresAddress()
+}
+
+// MysqlReplicaAddresses returns MySQL read replica connection addresses.
+func (c Config) MysqlReplicaAddresses() []string {
+	return c.MySql.MysqlReplicaAddresses()
+}
+
+// PostgresReplicaAddresses returns PostgreSQL read replica connection addresses.
+func (c Config) PostgresReplicaAddresses() []string {
+	return c.Postgres.PostgresReplicaAddresses()
+}
+
+// SQLiteAddress returns SQLite connection address.
+func (c Config) SQLiteAddress() string {
+	return c.SQLite.SQLiteAddress()
+}
+
+// SQLServerAddress returns Microsoft SQL Server connection address.
+func (c Config) SQLServerAddress() string {
+	return c.SQLServer.SQLServerAddress()
+}
+
+// ClickHouseAddress returns ClickHouse connection address.
+func (c Config) ClickHouseAddress() string {
+	return c.ClickHouse.ClickHouseAddress()
+}
+
+// RedisAddress returns Redis connection URL.
+func (c Config) RedisAddress() string {
+	return c.Redis.URL()
+}
+
+// PrometheusAddress returns the listen address of the Prometheus metrics endpoint.
+func (c Config) PrometheusAddress() string {
+	return c.Prometheus.GetAddress()
+}
+
+// RabbitMQAddress returns RabbitMQ connection URL.
+func (c Config) RabbitMQAddress() string {
+	return c.RabbitMQ.URL()
 }
@@ -0,0 +1,12 @@
+package config
+
+import "strings"
+
+// URL returns the NATS server URLs separated by commas, as accepted by nats.Connect,
+// defaulting to DefaultNATSURL when none is set.
+func (n NATS) URL() string {
+	if len(n.URLs) == 0 {
+		return DefaultNATSURL
+	}
+	return strings.Join(n.URLs, ",")
+}
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNATS_URL(t *testing.T) {
+	t.Run("should join server URLs", func(t *testing.T) {
+		nats := NATS{URLs: []string{"nats://nats1.domain:4222", "nats://nats2.domain:4222"}}
+		assert.Equal(t, "nats://nats1.domain:4222,nats://nats2.domain:4222", nats.URL())
+	})
+
+	t.Run("should default to a local server", func(t *testing.T) {
+		assert.Equal(t, DefaultNATSURL, NATS{}.URL())
+	})
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"encoding/xml"
+	"maps"
+	"strings"
+)
+
+// OpenTelemetry OTLP protocols.
+const (
+	OTelProtocolGRPC         = "grpc"
+	OTelProtocolHTTPProtobuf = "http/protobuf"
+	OTelProtocolHTTPJSON     = "http/json"
+)
+
+// OpenTelemetry signal exporters.
+const (
+	OTelExporterOTLP    = "otlp"
+	OTelExporterConsole = "console"
+	OTelExporterNone    = "none"
+)
+
+// OpenTelemetry resource attributes set from Config.
+const (
+	OTelServiceName           = "service.name"
+	OTelDeploymentEnvironment = "deployment.environment.name"
+)
+
+var (
+	otelProtocols = []string{OTelProtocolGRPC, OTelProtocolHTTPProtobuf, OTelProtocolHTTPJSON}
+	otelExporters = []string{OTelExporterOTLP, OTelExporterConsole, OTelExporterNone}
+)
+
+// Attributes holds string key-value pairs, as OpenTelemetry headers and resource attributes,
+// also supported by the xml loader.
+type Attributes map[string]string
+
+// UnmarshalXML decodes each nested element as an attribute named after the element.
+func (a *Attributes) UnmarshalXML(decoder *xml.Decoder, _ xml.StartElement) error {
+	return unmarshalXMLMap(decoder, (*map[string]string)(a))
+}
+
+// MarshalXML encodes each attribute as an element named after its key.
+func (a Attributes) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLMap(encoder, start, a)
+}
+
+// TracesExporter returns the traces exporter, completed with shared OTel values.
+func (o OTel) TracesExporter() OTelExporter {
+	return o.exporter(o.Traces, "/v1/traces")
+}
+
+// MetricsExporter returns the metrics exporter, completed with shared OTel values.
+func (o OTel) MetricsExporter() OTelExporter {
+	return o.exporter(o.Metrics, "/v1/metrics")
+}
+
+// LogsExporter returns the logs exporter, completed with shared OTel values.
+func (o OTel) LogsExporter() OTelExporter {
+	return o.exporter(o.Logs, "/v1/logs")
+}
+
+// exporter completes a signal exporter as the OTLP exporter specification does.
+// The shared endpoint is followed by the signal path over HTTP, and the exporter is none when OTel is disabled.
+func (o OTel) exporter(exporter OTelExporter, path string) OTelExporter {
+	if !o.Enabled {
+		exporter.Exporter = OTelExporterNone
+	} else if exporter.Exporter == "" {
+		exporter.Exporter = DefaultOTelExporter
+	}
+
+	if exporter.Protocol == "" {
+		exporter.Protocol = o.Protocol
+	}
+	if exporter.Endpoint == "" && o.Endpoint != "" {
+		exporter.Endpoint = o.Endpoint
+		if exporter.Protocol != OTelProtocolGRPC {
+			exporter.Endpoint = strings.TrimSuffix(o.Endpoint, "/") + path
+		}
+	}
+
+	if len(o.Headers) > 0 {
+		headers := maps.Clone(o.Headers)
+		maps.Copy(headers, exporter.Headers)
+		exporter.Headers = headers
+	}
+
+	return exporter
+}
+
+// OTelResource returns the OpenTelemetry resource attributes, setting service.name from the service identifier
+// and deployment.environment.name from the environment, unless given in OTel.ResourceAttributes.
+// OTel.ServiceName takes precedence over both.
+func (c Config) OTelResource() Attributes {
+	resource := make(Attributes, len(c.OTel.ResourceAttributes)+2)
+	if c.Service != "" {
+		resource[OTelServiceName] = c.Service
+	}
+	if c.Environment != "" {
+		resource[OTelDeploymentEnvironment] = c.Environment
+	}
+	maps.Copy(resource, c.OTel.ResourceAttributes)
+	if c.OTel.ServiceName != "" {
+		resource[OTelServiceName] = c.OTel.ServiceName
+	}
+
+	return resource
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOTel_Exporters(t *testing.T) {
+	otel := OTel{
+		Enabled:  true,
+		Endpoint: "http://otel.domain:4318/",
+		Protocol: OTelProtocolHTTPProtobuf,
+		Headers:  Attributes{"authorization": "Bearer otel.token"},
+		Traces: OTelExporter{
+			Headers: Attributes{"x-tenant": "shop"},
+		},
+		Metrics: OTelExporter{
+			Exporter: OTelExporterConsole,
+		},
+		Logs: OTelExporter{
+			Endpoint: "http://loki.domain:3100/otlp/v1/logs",
+			Headers:  Attributes{"authorization": "Bearer loki.token"},
+		},
+	}
+
+	t.Run("should append the signal path to the shared endpoint", func(t *testing.T) {
+		assert.Equal(t, OTelExporter{
+			Exporter: OTelExporterOTLP,
+			Endpoint: "http://otel.domain:4318/v1/traces",
+			Protocol: OTelProtocolHTTPProtobuf,
+			Headers:  Attributes{"authorization": "Bearer otel.token", "x-tenant": "shop"},
+		}, otel.TracesExporter())
+		assert.Equal(t, "http://otel.domain:4318/v1/metrics", otel.MetricsExporter().Endpoint)
+		assert.Equal(t, OTelExporterConsole, otel.MetricsExporter().Exporter)
+	})
+
+	t.Run("should keep signal values", func(t *testing.T) {
+		assert.Equal(t, OTelExporter{
+			Exporter: OTelExporterOTLP,
+			Endpoint: "http://loki.domain:3100/otlp/v1/logs",
+			Protocol: OTelProtocolHTTPProtobuf,
+			Headers:  Attributes{"authorization": "Bearer loki.token"},
+		}, otel.LogsExporter())
+	})
+
+	t.Run("should keep the shared endpoint over gRPC", func(t *testing.T) {
+		grpc := OTel{Enabled: true, Endpoint: "http://otel.domain:4317", Protocol: OTelProtocolGRPC}
+		assert.Equal(t, "http://otel.domain:4317", grpc.TracesExporter().Endpoint)
+	})
+
+	t.Run("should not export when disabled", func(t *testing.T) {
+		disabled := otel
+		disabled.Enabled = false
+		assert.Equal(t, OTelExporterNone, disabled.TracesExporter().Exporter)
+		assert.Equal(t, OTelExporterNone, disabled.MetricsExporter().Exporter)
+	})
+
+	t.Run("should not change the shared headers", func(t *testing.T) {
+		_ = otel.LogsExporter()
+		assert.Equal(t, Attributes{"authorization": "Bearer otel.token"}, otel.Headers)
+	})
+}
+
+func TestConfig_OTelResource(t *testing.T) {
+	t.Run("should set service and environment attributes", func(t *testing.T) {
+		cfg := Config{
+			Service:     "orders",
+			Environment: "prod",
+			OTel:        OTel{ResourceAttributes: Attributes{"service.namespace": "shop"}},
+		}
+
+		assert.Equal(t, Attributes{
+			OTelServiceName:           "orders",
+			OTelDeploymentEnvironment: "prod",
+			"service.namespace":       "shop",
+		}, cfg.OTelResource())
+	})
+
+	t.Run("should prefer resource attributes and the OTel service name", func(t *testing.T) {
+		cfg := Config{
+			Service:     "orders",
+			Environment: "prod",
+			OTel: OTel{
+				ServiceName:        "orders-api",
+				ResourceAttributes: Attributes{OTelServiceName: "orders-worker", OTelDeploymentEnvironment: "staging"},
+			},
+		}
+
+		assert.Equal(t, Attributes{
+			OTelServiceName:           "orders-api",
+			OTelDeploymentEnvironment: "staging",
+		}, cfg.OTelResource())
+	})
+
+	t.Run("should return no attributes for an empty config", func(t *testing.T) {
+		assert.Empty(t, Config{}.OTelResource())
+	})
+}
+
+func TestAttributes_XML(t *testing.T) {
+	t.Run("should decode and encode nested elements", func(t *testing.T) {
+		var decoded struct {
+			Headers Attributes `xml:"headers"`
+		}
+		content := `<otel><headers><authorization>Bearer otel.token</authorization></headers></otel>`
+
+		err := xml.Unmarshal([]byte(content), &decoded)
+		require.NoError(t, err)
+		assert.Equal(t, Attributes{"authorization": "Bearer otel.token"}, decoded.Headers)
+
+		encoded, err := xml.Marshal(struct {
+			XMLName xml.Name   `xml:"otel"`
+			Headers Attributes `xml:"headers"`
+		}{Headers: decoded.Headers})
+		require.NoError(t, err)
+		assert.Equal(t, content, string(encoded))
+	})
+}
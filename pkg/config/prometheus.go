@@ -0,0 +1,59 @@
+package config
+
+import (
+	"encoding/base64"
+	"net"
+	"strconv"
+)
+
+// GetAddress returns the listen address of the metrics endpoint, as host:port.
+func (p Prometheus) GetAddress() string {
+	return net.JoinHostPort(p.Host, strconv.Itoa(p.Port))
+}
+
+// MetricName returns a metric name prefixed by the namespace and subsystem, joined by underscores,
+// as prometheus.BuildFQName does.
+func (p Prometheus) MetricName(name string) string {
+	fqName := name
+	if p.Subsystem != "" {
+		fqName = p.Subsystem + "_" + fqName
+	}
+	if p.Namespace != "" {
+		fqName = p.Namespace + "_" + fqName
+	}
+	return fqName
+}
+
+// BasicAuthEnabled reports whether scraping requires basic authentication.
+func (p Prometheus) BasicAuthEnabled() bool {
+	return p.BasicAuth.Username != ""
+}
+
+// Authorization returns the Authorization header value required to scrape metrics: Basic credentials of BasicAuth,
+// or Bearer with the deprecated Token when BasicAuth is disabled. It is empty when scraping isn't authenticated.
+func (p Prometheus) Authorization() string {
+	switch {
+	case p.BasicAuthEnabled():
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(p.BasicAuth.Username+":"+p.BasicAuth.Password))
+	case p.Token != "":
+		return "Bearer " + p.Token
+	default:
+		return ""
+	}
+}
+
+// validMetricName reports whether a name matches [a-zA-Z_][a-zA-Z0-9_]*, as Prometheus namespaces and subsystems.
+func validMetricName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case '0' <= r && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
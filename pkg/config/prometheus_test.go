@@ -0,0 +1,86 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheus_GetAddress(t *testing.T) {
+	t.Run("should listen on every interface without host", func(t *testing.T) {
+		assert.Equal(t, ":2112", Prometheus{Port: DefaultPrometheusPort}.GetAddress())
+	})
+
+	t.Run("should join host and port", func(t *testing.T) {
+		assert.Equal(t, "127.0.0.1:9100", Prometheus{Host: "127.0.0.1", Port: 9100}.GetAddress())
+		assert.Equal(t, "[::1]:9100", Prometheus{Host: "::1", Port: 9100}.GetAddress())
+	})
+
+	t.Run("should return the address from Config", func(t *testing.T) {
+		cfg := Config{Prometheus: Prometheus{Host: serverHost, Port: 9100}}
+		assert.Equal(t, "localhost:9100", cfg.PrometheusAddress())
+	})
+}
+
+func TestPrometheus_MetricName(t *testing.T) {
+	tests := map[string]struct {
+		prometheus Prometheus
+		expected   string
+	}{
+		"with namespace and subsystem": {
+			prometheus: Prometheus{Namespace: "shop", Subsystem: "orders"},
+			expected:   "shop_orders_requests_total",
+		},
+		"with namespace": {
+			prometheus: Prometheus{Namespace: "shop"},
+			expected:   "shop_requests_total",
+		},
+		"without prefixes": {
+			expected: "requests_total",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.prometheus.MetricName("requests_total"))
+		})
+	}
+}
+
+func TestPrometheus_BasicAuthEnabled(t *testing.T) {
+	assert.True(t, Prometheus{BasicAuth: BasicAuth{Username: username, Password: password}}.BasicAuthEnabled())
+	assert.False(t, Prometheus{}.BasicAuthEnabled())
+}
+
+func TestPrometheus_Authorization(t *testing.T) {
+	t.Run("should return basic credentials", func(t *testing.T) {
+		prometheus := Prometheus{BasicAuth: BasicAuth{Username: "prometheus", Password: "secret"}, Token: "prometheus.token"}
+		assert.Equal(t, "Basic cHJvbWV0aGV1czpzZWNyZXQ=", prometheus.Authorization())
+	})
+
+	t.Run("should map the deprecated token to a bearer token", func(t *testing.T) {
+		assert.Equal(t, "Bearer prometheus.token", Prometheus{Token: "prometheus.token"}.Authorization())
+	})
+
+	t.Run("should return nothing without authentication", func(t *testing.T) {
+		assert.Empty(t, Prometheus{}.Authorization())
+	})
+}
+
+func TestValidMetricName(t *testing.T) {
+	tests := map[string]bool{
+		"shop":        true,
+		"_internal":   true,
+		"orders_v2":   true,
+		"":            false,
+		"2orders":     false,
+		"shop.orders": false,
+		"shop-api":    false,
+	}
+
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, validMetricName(name))
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RedactedValue replaces secret values in redacted configurations.
+const RedactedValue = "[REDACTED]"
+
+var sensitiveSettingKeys = []string{"password", "secret", "token", "key"}
+
+// Redacted returns a copy of the configuration with passwords, secrets and tokens masked.
+// Settings whose key looks sensitive, like "api_key", are masked too.
+func (c Config) Redacted() Config {
+	c.Token = c.Token.redacted()
+
+	c.MongoDb = c.MongoDb.redacted()
+	c.MySql = c.MySql.redacted()
+	c.Postgres = c.Postgres.redacted()
+	c.SQLite = c.SQLite.redacted()
+	c.SQLServer = c.SQLServer.redacted()
+	c.ClickHouse = c.ClickHouse.redacted()
+	if c.Databases != nil {
+		databases := make(Databases, len(c.Databases))
+		for name, db := range c.Databases {
+			databases[name] = db.redacted()
+		}
+		c.Databases = databases
+	}
+
+	c.Audit = c.Audit.redacted()
+	c.Jaeger = c.Jaeger.redacted()
+	c.Loki = c.Loki.redacted()
+	c.Tempo = c.Tempo.redacted()
+	c.Prometheus = c.Prometheus.redacted()
+	c.Redis = c.Redis.redacted()
+	if c.Services != nil {
+		services := make(Services, len(c.Services))
+		for name, service := range c.Services {
+			services[name] = service.redacted()
+		}
+		c.Services = services
+	}
+	c.OTel = c.OTel.redacted()
+	c.Kafka.SASL.Password = redact(c.Kafka.SASL.Password)
+	c.RabbitMQ.Password = redact(c.RabbitMQ.Password)
+	c.NATS = c.NATS.redacted()
+
+	if c.Settings != nil {
+		settings := make(map[string]string, len(c.Settings))
+		for key, value := range c.Settings {
+			if isSensitiveKey(key) {
+				value = redact(value)
+			}
+			settings[key] = value
+		}
+		c.Settings = settings
+	}
+
+	return c
+}
+
+func (t Token) redacted() Token {
+	t.Secret = redact(t.Secret)
+	if t.VerificationKeys != nil {
+		keys := make([]TokenKey, len(t.VerificationKeys))
+		for i, key := range t.VerificationKeys {
+			key.Secret = redact(key.Secret)
+			keys[i] = key
+		}
+		t.VerificationKeys = keys
+	}
+	return t
+}
+
+func (d Database) redacted() Database {
+	d.Password = redact(d.Password)
+	d.URL = redact(d.URL)
+	if d.Replicas != nil {
+		replicas := make([]Replica, len(d.Replicas))
+		for i, replica := range d.Replicas {
+			replica.Password = redact(replica.Password)
+			replicas[i] = replica
+		}
+		d.Replicas = replicas
+	}
+	return d
+}
+
+func (s ExternalService) redacted() ExternalService {
+	s.Token = redact(s.Token)
+	s.Headers = s.Headers.redacted()
+	return s
+}
+
+func (l Loki) redacted() Loki {
+	l.Token = redact(l.Token)
+	return l
+}
+
+func (p Prometheus) redacted() Prometheus {
+	p.BasicAuth.Password = redact(p.BasicAuth.Password)
+	p.Token = redact(p.Token)
+	p.PushgatewayURL = redactURL(p.PushgatewayURL)
+	return p
+}
+
+func (r Redis) redacted() Redis {
+	r.Password = redact(r.Password)
+	r.Token = redact(r.Token)
+	return r
+}
+
+func (o OTel) redacted() OTel {
+	o.Endpoint = redactURL(o.Endpoint)
+	o.Headers = o.Headers.redacted()
+	o.Traces = o.Traces.redacted()
+	o.Metrics = o.Metrics.redacted()
+	o.Logs = o.Logs.redacted()
+	return o
+}
+
+func (e OTelExporter) redacted() OTelExporter {
+	e.Endpoint = redactURL(e.Endpoint)
+	e.Headers = e.Headers.redacted()
+	return e
+}
+
+func (n NATS) redacted() NATS {
+	if n.URLs != nil {
+		urls := make([]string, len(n.URLs))
+		for i, rawURL := range n.URLs {
+			urls[i] = redactURL(rawURL)
+		}
+		n.URLs = urls
+	}
+	return n
+}
+
+// redacted masks every value, since OTLP headers usually carry credentials.
+func (a Attributes) redacted() Attributes {
+	if a == nil {
+		return nil
+	}
+	redacted := make(Attributes, len(a))
+	for key, value := range a {
+		redacted[key] = redact(value)
+	}
+	return redacted
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return RedactedValue
+}
+
+// redactURL masks the user information of a URL, as in nats://[REDACTED]@nats.domain:4222, since it can hold
+// a password or a token. Values that can't be parsed as a URL are masked entirely.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return redact(rawURL)
+	}
+	if u.User == nil {
+		return rawURL
+	}
+
+	u.User = nil
+	scheme, rest, _ := strings.Cut(u.String(), "//")
+	return scheme + "//" + RedactedValue + "@" + rest
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveSettingKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
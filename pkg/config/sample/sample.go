@@ -0,0 +1,223 @@
+package sample
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ribeirohugo/go_config/v2/pkg/config"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/file"
+)
+
+const indent = "  "
+
+// Generate returns a sample configuration in a given format, holding every field with its default value.
+// Fields are documented with their descriptions, except for json, which doesn't support comments.
+// Supported formats are file.FormatTOML, file.FormatYAML, file.FormatJSON and file.FormatDotenv.
+func Generate(format string) ([]byte, error) {
+	var buf bytes.Buffer
+	fields := config.Fields()
+
+	switch format {
+	case file.FormatTOML:
+		writeTOML(&buf, fields, "")
+	case file.FormatYAML:
+		writeYAML(&buf, fields, 0)
+	case file.FormatJSON:
+		writeJSON(&buf, fields, 0)
+		buf.WriteString("\n")
+	case file.FormatDotenv:
+		writeDotenv(&buf, fields, "")
+	default:
+		return nil, fmt.Errorf("unsupported sample format: %s", format)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeTOML writes plain keys first and tables afterward, since keys following a table header belong to it.
+func writeTOML(buf *bytes.Buffer, fields []config.Field, table string) {
+	for _, field := range fields {
+		if isTable(field) {
+			continue
+		}
+		writeComment(buf, field.Description, "")
+		_, _ = fmt.Fprintf(buf, "%s = %s\n", field.Key, formatValue(field.Default))
+	}
+
+	for _, field := range fields {
+		if !isTable(field) {
+			continue
+		}
+		name := joinKey(table, field.Key, ".")
+
+		buf.WriteString("\n")
+		writeComment(buf, field.Description, "")
+		_, _ = fmt.Fprintf(buf, "[%s]\n", name)
+		if field.Type.Kind() == reflect.Struct {
+			writeTOML(buf, field.Fields, name)
+		}
+	}
+}
+
+func writeYAML(buf *bytes.Buffer, fields []config.Field, depth int) {
+	prefix := strings.Repeat(indent, depth)
+
+	for i, field := range fields {
+		if depth == 0 && i > 0 && (isTable(field) || isTable(fields[i-1])) {
+			buf.WriteString("\n")
+		}
+		writeComment(buf, field.Description, prefix)
+
+		switch {
+		case field.Type.Kind() == reflect.Struct && !isText(field.Default):
+			_, _ = fmt.Fprintf(buf, "%s%s:\n", prefix, field.Key)
+			writeYAML(buf, field.Fields, depth+1)
+		case field.Type.Kind() == reflect.Map:
+			_, _ = fmt.Fprintf(buf, "%s%s: {}\n", prefix, field.Key)
+		default:
+			_, _ = fmt.Fprintf(buf, "%s%s: %s\n", prefix, field.Key, formatValue(field.Default))
+		}
+	}
+}
+
+func writeJSON(buf *bytes.Buffer, fields []config.Field, depth int) {
+	prefix := strings.Repeat(indent, depth+1)
+
+	buf.WriteString("{\n")
+	for i, field := range fields {
+		_, _ = fmt.Fprintf(buf, "%s%q: ", prefix, field.Key)
+
+		if field.Type.Kind() == reflect.Struct && !isText(field.Default) {
+			writeJSON(buf, field.Fields, depth+1)
+		} else {
+			content, _ := json.Marshal(field.Default)
+			if field.Type.Kind() == reflect.Map || field.Type.Kind() == reflect.Slice {
+				content = emptyJSONCollection(field, content)
+			}
+			buf.Write(content)
+		}
+
+		if i < len(fields)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	_, _ = fmt.Fprintf(buf, "%s}", strings.Repeat(indent, depth))
+}
+
+func writeDotenv(buf *bytes.Buffer, fields []config.Field, prefix string) {
+	for i, field := range fields {
+		name := strings.ToUpper(joinKey(prefix, field.Key, "_"))
+		isGroup := field.Type.Kind() == reflect.Struct && !isText(field.Default)
+		isNamedGroup := field.Type.Kind() == reflect.Map && field.Fields != nil
+
+		if buf.Len() > 0 && (isGroup || isNamedGroup || (i > 0 && isTable(fields[i-1]))) {
+			buf.WriteString("\n")
+		}
+		if isGroup {
+			writeComment(buf, field.Description, "")
+			writeDotenv(buf, field.Fields, name)
+			continue
+		}
+		if isNamedGroup {
+			writeComment(buf, field.Description, "")
+			writeDotenvExample(buf, field.Fields, name+"_<NAME>")
+			continue
+		}
+
+		writeComment(buf, field.Description, "")
+		_, _ = fmt.Fprintf(buf, "%s=%s\n", name, formatEnvValue(field.Default))
+	}
+}
+
+// writeDotenvExample writes commented variables for map entries, whose names are chosen by users.
+func writeDotenvExample(buf *bytes.Buffer, fields []config.Field, prefix string) {
+	for _, field := range fields {
+		_, _ = fmt.Fprintf(buf, "# %s=\n", strings.ToUpper(joinKey(prefix, field.Key, "_")))
+	}
+}
+
+func writeComment(buf *bytes.Buffer, description string, prefix string) {
+	if description == "" {
+		return
+	}
+	_, _ = fmt.Fprintf(buf, "%s# %s\n", prefix, description)
+}
+
+// isTable reports whether a field is written as a toml table.
+func isTable(field config.Field) bool {
+	kind := field.Type.Kind()
+	return (kind == reflect.Struct && !isText(field.Default)) || kind == reflect.Map
+}
+
+// isText reports whether a value is written as text, instead of its inner fields.
+func isText(value any) bool {
+	_, ok := value.(encoding.TextMarshaler)
+	return ok
+}
+
+// formatValue formats a value as a toml or yaml flow value. Both formats share this syntax.
+func formatValue(value any) string {
+	if text, ok := value.(encoding.TextMarshaler); ok {
+		content, _ := text.MarshalText()
+		return strconv.Quote(string(content))
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.String:
+		return strconv.Quote(reflectValue.String())
+	case reflect.Slice:
+		items := make([]string, 0, reflectValue.Len())
+		for i := range reflectValue.Len() {
+			items = append(items, formatValue(reflectValue.Index(i).Interface()))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func formatEnvValue(value any) string {
+	if text, ok := value.(encoding.TextMarshaler); ok {
+		content, _ := text.MarshalText()
+		return string(content)
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Slice:
+		items := make([]string, 0, reflectValue.Len())
+		for i := range reflectValue.Len() {
+			items = append(items, formatEnvValue(reflectValue.Index(i).Interface()))
+		}
+		return strings.Join(items, ",")
+	case reflect.Map:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// emptyJSONCollection replaces null collections by empty ones, which read better in samples.
+func emptyJSONCollection(field config.Field, content []byte) []byte {
+	if string(content) != "null" {
+		return content
+	}
+	if field.Type.Kind() == reflect.Map {
+		return []byte("{}")
+	}
+	return []byte("[]")
+}
+
+func joinKey(prefix string, key string, separator string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + separator + key
+}
@@ -0,0 +1,102 @@
+package sample
+
+import (
+	"testing"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ribeirohugo/go_config/v2/pkg/config"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/file"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/json"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/toml"
+	"github.com/ribeirohugo/go_config/v2/pkg/config/yaml"
+)
+
+func TestGenerate(t *testing.T) {
+	expectedConfig := config.Default()
+	expectedConfig.Server.AllowedOrigins = []string{}
+	expectedConfig.Server.CORS.AllowedMethods = []string{}
+	expectedConfig.Server.CORS.AllowedHeaders = []string{}
+	expectedConfig.Server.CORS.ExposedHeaders = []string{}
+	expectedConfig.Token.Audience = []string{}
+	expectedConfig.Token.VerificationKeys = []config.TokenKey{}
+	expectedConfig.MongoDb.Replicas = []config.Replica{}
+	expectedConfig.MongoDb.Hosts = []string{}
+	expectedConfig.MySql.Replicas = []config.Replica{}
+	expectedConfig.Postgres.Replicas = []config.Replica{}
+	expectedConfig.SQLite.Replicas = []config.Replica{}
+	expectedConfig.SQLite.Pragmas = []string{}
+	expectedConfig.SQLServer.Replicas = []config.Replica{}
+	expectedConfig.ClickHouse.Replicas = []config.Replica{}
+	expectedConfig.Databases = config.Databases{}
+	expectedConfig.Services = config.Services{}
+	expectedConfig.Redis.SentinelAddresses = []string{}
+	expectedConfig.Redis.ClusterAddresses = []string{}
+	expectedConfig.Kafka.Brokers = []string{}
+	expectedConfig.NATS.URLs = []string{}
+	expectedConfig.OTel.ResourceAttributes = config.Attributes{}
+	expectedConfig.OTel.Headers = config.Attributes{}
+	expectedConfig.OTel.Traces.Headers = config.Attributes{}
+	expectedConfig.OTel.Metrics.Headers = config.Attributes{}
+	expectedConfig.OTel.Logs.Headers = config.Attributes{}
+	expectedConfig.Log.Levels = config.Attributes{}
+	expectedConfig.Loki.Labels = config.Attributes{}
+	expectedConfig.Audit.Headers = config.Attributes{}
+	expectedConfig.Jaeger.Headers = config.Attributes{}
+	expectedConfig.Tempo.Headers = config.Attributes{}
+	expectedConfig.Settings = map[string]string{}
+
+	loaders := map[string]func([]byte) (config.Config, error){
+		file.FormatTOML: toml.LoadContent,
+		file.FormatYAML: yaml.LoadContent,
+		file.FormatJSON: json.LoadContent,
+	}
+
+	t.Run("should return a sample loadable with default values", func(t *testing.T) {
+		for format, loadContent := range loaders {
+			content, err := Generate(format)
+			require.NoError(t, err, format)
+
+			cfg, err := loadContent(content)
+			require.NoError(t, err, format)
+			assert.Equal(t, expectedConfig, cfg, format)
+		}
+	})
+
+	t.Run("should document fields with comments", func(t *testing.T) {
+		content, err := Generate(file.FormatTOML)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "# HTTP server configuration.\n[server]\n# HTTP server host name.\nhost = \"\"\n")
+
+		content, err = Generate(file.FormatYAML)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "postgres:\n  # Database host.\n  host: \"\"\n")
+	})
+
+	t.Run("should return a dotenv sample with environment variable names", func(t *testing.T) {
+		content, err := Generate(file.FormatDotenv)
+		require.NoError(t, err)
+
+		values, err := godotenv.UnmarshalBytes(content)
+		require.NoError(t, err)
+		assert.Equal(t, "5432", values["POSTGRES_PORT"])
+		assert.Equal(t, config.DefaultMigrationsPostgres, values["POSTGRES_MIGRATIONS_PATH"])
+		assert.Equal(t, config.DefaultRedisHost, values["REDIS_HOST"])
+		assert.Contains(t, values, "SERVER_ALLOWED_ORIGINS")
+		assert.Contains(t, values, "ENVIRONMENT")
+		assert.Contains(t, string(content), "# DATABASES_<NAME>_HOST=\n")
+		assert.Contains(t, values, "MONGODB_REPLICA_SET")
+		assert.NotContains(t, values, "POSTGRES_REPLICA_SET")
+		assert.NotContains(t, values, "MYSQL_HOSTS")
+		assert.Contains(t, values, "SQLITE_PRAGMAS")
+		assert.NotContains(t, values, "POSTGRES_PATH")
+	})
+
+	t.Run("should return an error for unsupported formats", func(t *testing.T) {
+		content, err := Generate("ini")
+		assert.Nil(t, content)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+
+	"github.com/ribeirohugo/go_config/v2/pkg/config"
+)
+
+// Draft is the JSON Schema dialect of generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+const title = "go_config configuration"
+
+// Schema holds a JSON Schema definition.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Default              any                `json:"default,omitempty"`
+}
+
+// New returns the JSON Schema of config.Config, with descriptions, required fields and default values.
+func New() *Schema {
+	schema := object(config.Fields())
+	schema.Schema = Draft
+	schema.Title = title
+
+	return schema
+}
+
+// Generate returns the JSON Schema of config.Config, encoded as indented json.
+func Generate() ([]byte, error) {
+	content, err := json.MarshalIndent(New(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(content, '\n'), nil
+}
+
+func object(fields []config.Field) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema, len(fields)),
+	}
+
+	for _, field := range fields {
+		property := fromType(field.Type)
+		property.Description = field.Description
+		if field.Fields != nil && field.Type.Kind() == reflect.Map {
+			property.AdditionalProperties = object(field.Fields)
+		} else if field.Fields != nil && field.Type.Kind() == reflect.Slice {
+			property.Items = object(field.Fields)
+		} else if field.Fields != nil {
+			nested := object(field.Fields)
+			property.Properties = nested.Properties
+			property.Required = nested.Required
+		} else if !reflect.ValueOf(field.Default).IsZero() {
+			property.Default = field.Default
+		}
+
+		schema.Properties[field.Key] = property
+		if field.Required {
+			schema.Required = append(schema.Required, field.Key)
+		}
+	}
+
+	return schema
+}
+
+var (
+	durationType      = reflect.TypeFor[config.Duration]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+func fromType(fieldType reflect.Type) *Schema {
+	if fieldType == durationType {
+		return &Schema{AnyOf: []*Schema{{Type: "string"}, {Type: "integer"}}}
+	}
+	if fieldType.Implements(textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: fromType(fieldType.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: fromType(fieldType.Elem())}
+	case reflect.Struct:
+		return &Schema{Type: "object"}
+	default:
+		return &Schema{Type: "string"}
+	}
+}
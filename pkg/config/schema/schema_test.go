@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ribeirohugo/go_config/v2/pkg/config"
+)
+
+func TestNew(t *testing.T) {
+	schema := New()
+
+	t.Run("should describe the root object", func(t *testing.T) {
+		assert.Equal(t, Draft, schema.Schema)
+		assert.Equal(t, "object", schema.Type)
+		assert.Equal(t, []string{"server"}, schema.Required)
+	})
+
+	t.Run("should describe nested types and required fields", func(t *testing.T) {
+		server := schema.Properties["server"]
+		require.NotNil(t, server)
+		assert.Equal(t, "object", server.Type)
+		assert.Equal(t, "HTTP server configuration.", server.Description)
+		assert.Equal(t, []string{"host", "port"}, server.Required)
+		assert.Equal(t, "integer", server.Properties["port"].Type)
+		assert.Equal(t, &Schema{
+			Description: "Origins allowed by CORS, as https://*.example.com or * for any.",
+			Type:        "array",
+			Items:       &Schema{Type: "string"},
+		}, server.Properties["allowed_origins"])
+	})
+
+	t.Run("should hold default values", func(t *testing.T) {
+		postgres := schema.Properties["postgres"]
+		require.NotNil(t, postgres)
+		assert.Equal(t, config.DefaultPostgresPort, postgres.Properties["port"].Default)
+		assert.Equal(t, config.DefaultMigrationsPostgres, postgres.Properties["migrations_path"].Default)
+		assert.Nil(t, postgres.Properties["host"].Default)
+
+		redis := schema.Properties["redis"]
+		require.NotNil(t, redis)
+		assert.Equal(t, config.DefaultRedisHost, redis.Properties["host"].Default)
+	})
+
+	t.Run("should describe durations as strings or integer seconds", func(t *testing.T) {
+		token := schema.Properties["token"]
+		require.NotNil(t, token)
+		assert.Equal(t, []*Schema{{Type: "string"}, {Type: "integer"}}, token.Properties["max_age"].AnyOf)
+		assert.Equal(t, config.Duration(24*time.Hour), token.Properties["max_age"].Default)
+	})
+
+	t.Run("should describe maps", func(t *testing.T) {
+		assert.Equal(t, &Schema{
+			Description:          "Custom key-value settings.",
+			Type:                 "object",
+			AdditionalProperties: &Schema{Type: "string"},
+		}, schema.Properties["settings"])
+	})
+
+	t.Run("should describe maps of objects", func(t *testing.T) {
+		databases := schema.Properties["databases"]
+		require.NotNil(t, databases)
+		assert.Equal(t, "object", databases.Type)
+		require.NotNil(t, databases.AdditionalProperties)
+		assert.Equal(t, "string", databases.AdditionalProperties.Properties["driver"].Type)
+		assert.Equal(t, "integer", databases.AdditionalProperties.Properties["port"].Type)
+	})
+
+	t.Run("should describe slices of objects", func(t *testing.T) {
+		replicas := schema.Properties["postgres"].Properties["replicas"]
+		require.NotNil(t, replicas)
+		assert.Equal(t, "array", replicas.Type)
+		require.NotNil(t, replicas.Items)
+		assert.Equal(t, "string", replicas.Items.Properties["host"].Type)
+		assert.Equal(t, "integer", replicas.Items.Properties["port"].Type)
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("should return a json encoded schema", func(t *testing.T) {
+		content, err := Generate()
+		require.NoError(t, err)
+
+		var decoded map[string]any
+		err = json.Unmarshal(content, &decoded)
+		require.NoError(t, err)
+		assert.Equal(t, Draft, decoded["$schema"])
+		assert.Contains(t, decoded["properties"], "token")
+	})
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// HTTPServer returns an HTTP server serving handler, set with the configured address, timeouts and TLS.
+// When TLS is enabled, certificates are already loaded, so the server is started with ListenAndServeTLS("", "").
+func (s Server) HTTPServer(handler http.Handler) (*http.Server, error) {
+	tlsConfig, err := s.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Server{
+		Addr:              s.GetAddress(),
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadTimeout:       s.ReadTimeout.Duration(),
+		ReadHeaderTimeout: s.ReadHeaderTimeout.Duration(),
+		WriteTimeout:      s.WriteTimeout.Duration(),
+		IdleTimeout:       s.IdleTimeout.Duration(),
+		MaxHeaderBytes:    s.MaxHeaderBytes,
+	}, nil
+}
+
+// TLSConfig returns a TLS configuration holding the configured certificate and minimum version.
+// It returns nil when TLS is not enabled.
+func (s Server) TLSConfig() (*tls.Config, error) {
+	if !s.TLSEnabled() {
+		return nil, nil
+	}
+
+	minVersion, err := s.tlsMinVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(s.TLSCertFile, s.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS certificate: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   minVersion,
+	}, nil
+}
+
+// tlsMinVersion returns the TLS version matching TLSMinVersion, defaulting to TLS 1.2 when empty.
+func (s Server) tlsMinVersion() (uint16, error) {
+	if s.TLSMinVersion == "" {
+		return tls.VersionTLS12, nil
+	}
+
+	version, ok := tlsVersions[s.TLSMinVersion]
+	if !ok {
+		return 0, fmt.Errorf("invalid TLS version %q, must be 1.0, 1.1, 1.2 or 1.3", s.TLSMinVersion)
+	}
+	return version, nil
+}
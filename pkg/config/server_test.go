@@ -0,0 +1,123 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_HTTPServer(t *testing.T) {
+	handler := http.NotFoundHandler()
+
+	t.Run("should return a server with address and timeouts", func(t *testing.T) {
+		server := Default().Server
+		server.Host = serverHost
+		server.Port = serverPort
+
+		httpServer, err := server.HTTPServer(handler)
+		require.NoError(t, err)
+		assert.Equal(t, "localhost:8080", httpServer.Addr)
+		assert.NotNil(t, httpServer.Handler)
+		assert.Nil(t, httpServer.TLSConfig)
+		assert.Equal(t, 15*time.Second, httpServer.ReadTimeout)
+		assert.Equal(t, 5*time.Second, httpServer.ReadHeaderTimeout)
+		assert.Equal(t, 15*time.Second, httpServer.WriteTimeout)
+		assert.Equal(t, time.Minute, httpServer.IdleTimeout)
+		assert.Equal(t, 1<<20, httpServer.MaxHeaderBytes)
+	})
+
+	t.Run("should return a server with TLS configuration", func(t *testing.T) {
+		certFile, keyFile := createCertificate(t)
+		server := Server{
+			Host:          serverHost,
+			Port:          serverPort,
+			TLSCertFile:   certFile,
+			TLSKeyFile:    keyFile,
+			TLSMinVersion: "1.3",
+		}
+
+		httpServer, err := server.HTTPServer(handler)
+		require.NoError(t, err)
+		require.NotNil(t, httpServer.TLSConfig)
+		assert.Len(t, httpServer.TLSConfig.Certificates, 1)
+		assert.Equal(t, uint16(tls.VersionTLS13), httpServer.TLSConfig.MinVersion)
+	})
+
+	t.Run("should return an error for missing certificate files", func(t *testing.T) {
+		server := Server{
+			TLSCertFile: filepath.Join(t.TempDir(), "missing.crt"),
+			TLSKeyFile:  filepath.Join(t.TempDir(), "missing.key"),
+		}
+
+		httpServer, err := server.HTTPServer(handler)
+		assert.Nil(t, httpServer)
+		assert.ErrorContains(t, err, "loading TLS certificate")
+	})
+}
+
+func TestServer_TLSConfig(t *testing.T) {
+	t.Run("should return nil without TLS files", func(t *testing.T) {
+		tlsConfig, err := Server{}.TLSConfig()
+		require.NoError(t, err)
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("should default to TLS 1.2", func(t *testing.T) {
+		certFile, keyFile := createCertificate(t)
+
+		tlsConfig, err := Server{TLSCertFile: certFile, TLSKeyFile: keyFile}.TLSConfig()
+		require.NoError(t, err)
+		assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+	})
+
+	t.Run("should return an error for an invalid TLS version", func(t *testing.T) {
+		certFile, keyFile := createCertificate(t)
+
+		_, err := Server{TLSCertFile: certFile, TLSKeyFile: keyFile, TLSMinVersion: "2.0"}.TLSConfig()
+		assert.EqualError(t, err, `invalid TLS version "2.0", must be 1.0, 1.1, 1.2 or 1.3`)
+	})
+}
+
+// createCertificate writes a self-signed certificate and its key to a temporary directory.
+func createCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: serverHost},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0o600)
+	require.NoError(t, err)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	require.NoError(t, err)
+
+	return certFile, keyFile
+}
@@ -0,0 +1,298 @@
+package config
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Token signing algorithms, as JWT alg header values.
+const (
+	TokenHS256 = "HS256"
+	TokenHS384 = "HS384"
+	TokenHS512 = "HS512"
+	TokenRS256 = "RS256"
+	TokenRS384 = "RS384"
+	TokenRS512 = "RS512"
+	TokenPS256 = "PS256"
+	TokenPS384 = "PS384"
+	TokenPS512 = "PS512"
+	TokenES256 = "ES256"
+	TokenES384 = "ES384"
+	TokenES512 = "ES512"
+	TokenEdDSA = "EdDSA"
+)
+
+// TokenMinRSABits is the minimum size of RSA token keys.
+const TokenMinRSABits = 2048
+
+var tokenAlgorithms = []string{
+	TokenHS256, TokenHS384, TokenHS512,
+	TokenRS256, TokenRS384, TokenRS512,
+	TokenPS256, TokenPS384, TokenPS512,
+	TokenES256, TokenES384, TokenES512,
+	TokenEdDSA,
+}
+
+var tokenCurves = map[string]elliptic.Curve{
+	TokenES256: elliptic.P256(),
+	TokenES384: elliptic.P384(),
+	TokenES512: elliptic.P521(),
+}
+
+// GetAlgorithm returns the signing algorithm, defaulting to HS256.
+func (t Token) GetAlgorithm() string {
+	if t.Algorithm == "" {
+		return DefaultTokenAlgorithm
+	}
+	return t.Algorithm
+}
+
+// Symmetric reports whether the signing algorithm is an HMAC one, signing and verifying tokens with secrets.
+func (t Token) Symmetric() bool {
+	return strings.HasPrefix(t.GetAlgorithm(), "HS")
+}
+
+// configured reports whether the token block is in use: an algorithm other than DefaultTokenAlgorithm,
+// or key material, is set.
+func (t Token) configured() bool {
+	return (t.Algorithm != "" && t.Algorithm != DefaultTokenAlgorithm) || t.Secret != "" || t.KeyID != "" ||
+		t.PrivateKeyFile != "" || t.PublicKeyFile != "" || len(t.VerificationKeys) > 0
+}
+
+// SigningKey returns the key signing tokens: Secret, as []byte, for HMAC algorithms,
+// or the private key of PrivateKeyFile, as *rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey.
+func (t Token) SigningKey() (crypto.PrivateKey, error) {
+	algorithm, err := t.algorithm()
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Symmetric() {
+		if t.Secret == "" {
+			return nil, fmt.Errorf("token secret is required with algorithm %s", algorithm)
+		}
+		return []byte(t.Secret), nil
+	}
+
+	if t.PrivateKeyFile == "" {
+		return nil, fmt.Errorf("token private key file is required with algorithm %s", algorithm)
+	}
+	return t.privateKey(algorithm)
+}
+
+// VerifyingKeys returns the keys verifying tokens by key identifier: the current key, under KeyID, and VerificationKeys.
+// Keys are secrets, as []byte, for HMAC algorithms, or public keys, as *rsa.PublicKey, *ecdsa.PublicKey
+// or ed25519.PublicKey. The current public key is read from PublicKeyFile, or derived from the private key.
+func (t Token) VerifyingKeys() (map[string]crypto.PublicKey, error) {
+	algorithm, err := t.algorithm()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(t.VerificationKeys)+1)
+	key, err := t.publicKey(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		keys[t.KeyID] = key
+	}
+
+	for _, verificationKey := range t.VerificationKeys {
+		if _, ok := keys[verificationKey.KeyID]; ok {
+			return nil, fmt.Errorf("duplicate token key id %q", verificationKey.KeyID)
+		}
+		key, err := verificationKey.verifyingKey(algorithm)
+		if err != nil {
+			return nil, fmt.Errorf("token key %q: %w", verificationKey.KeyID, err)
+		}
+		keys[verificationKey.KeyID] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no token verifying key")
+	}
+	return keys, nil
+}
+
+// publicKey returns the current verifying key, if any: Secret, or the public key of PublicKeyFile or PrivateKeyFile.
+func (t Token) publicKey(algorithm string) (crypto.PublicKey, error) {
+	switch {
+	case t.Symmetric():
+		if t.Secret == "" {
+			return nil, nil
+		}
+		return []byte(t.Secret), nil
+	case t.PublicKeyFile != "":
+		key, err := loadTokenPublicKey(algorithm, t.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading token public key: %w", err)
+		}
+		return key, nil
+	case t.PrivateKeyFile != "":
+		key, err := t.privateKey(algorithm)
+		if err != nil {
+			return nil, err
+		}
+		return key.Public(), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (t Token) privateKey(algorithm string) (crypto.Signer, error) {
+	key, err := loadTokenPrivateKey(algorithm, t.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading token private key: %w", err)
+	}
+	return key, nil
+}
+
+func (k TokenKey) verifyingKey(algorithm string) (crypto.PublicKey, error) {
+	if strings.HasPrefix(algorithm, "HS") {
+		if k.Secret == "" {
+			return nil, fmt.Errorf("secret is required with algorithm %s", algorithm)
+		}
+		return []byte(k.Secret), nil
+	}
+
+	if k.PublicKeyFile == "" {
+		return nil, fmt.Errorf("public key file is required with algorithm %s", algorithm)
+	}
+	key, err := loadTokenPublicKey(algorithm, k.PublicKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading public key: %w", err)
+	}
+	return key, nil
+}
+
+// algorithm returns the signing algorithm, or an error if unsupported.
+func (t Token) algorithm() (string, error) {
+	algorithm := t.GetAlgorithm()
+	if !slices.Contains(tokenAlgorithms, algorithm) {
+		return "", fmt.Errorf("unsupported token algorithm %q, must be one of %s", algorithm, strings.Join(tokenAlgorithms, ", "))
+	}
+	return algorithm, nil
+}
+
+// loadTokenPrivateKey reads a private key file, as loadPrivateKey, checking it matches a signing algorithm.
+func loadTokenPrivateKey(algorithm string, path string) (crypto.Signer, error) {
+	key, err := loadPrivateKey(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTokenKey(algorithm, key.Public()); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// loadTokenPublicKey reads a public key file, as loadPublicKey, checking it matches a signing algorithm.
+func loadTokenPublicKey(algorithm string, path string) (crypto.PublicKey, error) {
+	key, err := loadPublicKey(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTokenKey(algorithm, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// checkTokenKey reports a public key not matching a signing algorithm, or an RSA key too short.
+func checkTokenKey(algorithm string, key crypto.PublicKey) error {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(algorithm, "RS") && !strings.HasPrefix(algorithm, "PS") {
+			break
+		}
+		if key.N.BitLen() < TokenMinRSABits {
+			return fmt.Errorf("RSA key of %d bits, must be at least %d", key.N.BitLen(), TokenMinRSABits)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if curve, ok := tokenCurves[algorithm]; ok && key.Curve == curve {
+			return nil
+		}
+		return fmt.Errorf("ECDSA key of curve %s does not match algorithm %s", key.Curve.Params().Name, algorithm)
+	case ed25519.PublicKey:
+		if algorithm == TokenEdDSA {
+			return nil
+		}
+	}
+	return fmt.Errorf("key of type %T does not match algorithm %s", key, algorithm)
+}
+
+// loadPrivateKey reads a PKCS #8, PKCS #1 RSA or SEC 1 EC private key from a PEM file.
+func loadPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var key any
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key of type %T", key)
+	}
+	return signer, nil
+}
+
+// loadPublicKey reads a PKIX or PKCS #1 RSA public key, or the public key of a certificate, from a PEM file.
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return certificate.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	return block, nil
+}
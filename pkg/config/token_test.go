@@ -0,0 +1,209 @@
+package config
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToken_SigningKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, TokenMinRSABits)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("should return the secret of HMAC algorithms", func(t *testing.T) {
+		key, err := Token{Secret: "secret"}.SigningKey()
+		require.NoError(t, err)
+		assert.Equal(t, []byte("secret"), key)
+	})
+
+	t.Run("should load private keys", func(t *testing.T) {
+		ecDER, err := x509.MarshalECPrivateKey(ecKey)
+		require.NoError(t, err)
+		rsaDER := x509.MarshalPKCS1PrivateKey(rsaKey)
+
+		tests := map[string]struct {
+			token    Token
+			expected crypto.PrivateKey
+		}{
+			"RSA PKCS #1": {
+				token:    Token{Algorithm: TokenRS256, PrivateKeyFile: writeKey(t, "RSA PRIVATE KEY", rsaDER)},
+				expected: rsaKey,
+			},
+			"RSA PKCS #8": {
+				token:    Token{Algorithm: TokenPS512, PrivateKeyFile: writePKCS8Key(t, rsaKey)},
+				expected: rsaKey,
+			},
+			"EC SEC 1": {
+				token:    Token{Algorithm: TokenES256, PrivateKeyFile: writeKey(t, "EC PRIVATE KEY", ecDER)},
+				expected: ecKey,
+			},
+			"Ed25519 PKCS #8": {
+				token:    Token{Algorithm: TokenEdDSA, PrivateKeyFile: writePKCS8Key(t, edKey)},
+				expected: edKey,
+			},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				key, err := tt.token.SigningKey()
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, key)
+			})
+		}
+	})
+
+	t.Run("should return an error for keys not matching the algorithm", func(t *testing.T) {
+		_, err := Token{Algorithm: TokenES384, PrivateKeyFile: writePKCS8Key(t, ecKey)}.SigningKey()
+		assert.EqualError(t, err, "loading token private key: ECDSA key of curve P-256 does not match algorithm ES384")
+
+		_, err = Token{Algorithm: TokenEdDSA, PrivateKeyFile: writePKCS8Key(t, rsaKey)}.SigningKey()
+		assert.EqualError(t, err, "loading token private key: key of type *rsa.PublicKey does not match algorithm EdDSA")
+	})
+
+	t.Run("should return an error for short RSA keys", func(t *testing.T) {
+		shortKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+
+		_, err = Token{Algorithm: TokenRS256, PrivateKeyFile: writePKCS8Key(t, shortKey)}.SigningKey()
+		assert.EqualError(t, err, "loading token private key: RSA key of 1024 bits, must be at least 2048")
+	})
+
+	t.Run("should return an error for missing keys", func(t *testing.T) {
+		_, err := Token{}.SigningKey()
+		assert.EqualError(t, err, "token secret is required with algorithm HS256")
+
+		_, err = Token{Algorithm: TokenRS256}.SigningKey()
+		assert.EqualError(t, err, "token private key file is required with algorithm RS256")
+
+		_, err = Token{Algorithm: TokenRS256, PrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem")}.SigningKey()
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("should return an error for invalid key files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token.pem")
+		require.NoError(t, os.WriteFile(path, []byte("secret"), 0o600))
+
+		_, err := Token{Algorithm: TokenRS256, PrivateKeyFile: path}.SigningKey()
+		assert.EqualError(t, err, "loading token private key: no PEM block found")
+
+		_, err = Token{Algorithm: TokenRS256, PrivateKeyFile: writeKey(t, "PUBLIC KEY", nil)}.SigningKey()
+		assert.EqualError(t, err, `loading token private key: unsupported PEM block "PUBLIC KEY"`)
+	})
+
+	t.Run("should return an error for unsupported algorithms", func(t *testing.T) {
+		_, err := Token{Algorithm: "none", Secret: "secret"}.SigningKey()
+		assert.ErrorContains(t, err, `unsupported token algorithm "none"`)
+	})
+}
+
+func TestToken_VerifyingKeys(t *testing.T) {
+	t.Run("should return the current and previous secrets", func(t *testing.T) {
+		token := Token{
+			Secret: "current.secret",
+			KeyID:  "2024-06",
+			VerificationKeys: []TokenKey{
+				{KeyID: "2024-01", Secret: "previous.secret"},
+			},
+		}
+
+		keys, err := token.VerifyingKeys()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]crypto.PublicKey{
+			"2024-06": []byte("current.secret"),
+			"2024-01": []byte("previous.secret"),
+		}, keys)
+	})
+
+	t.Run("should return the current and previous public keys", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		previousKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		previousDER, err := x509.MarshalPKIXPublicKey(&previousKey.PublicKey)
+		require.NoError(t, err)
+		certFile, _ := createCertificate(t)
+
+		token := Token{
+			Algorithm:      TokenES256,
+			KeyID:          "2024-06",
+			PrivateKeyFile: writePKCS8Key(t, key),
+			VerificationKeys: []TokenKey{
+				{KeyID: "2024-01", PublicKeyFile: writeKey(t, "PUBLIC KEY", previousDER)},
+				{KeyID: "2023-06", PublicKeyFile: certFile},
+			},
+		}
+
+		keys, err := token.VerifyingKeys()
+		require.NoError(t, err)
+		require.Len(t, keys, 3)
+		assert.Equal(t, &key.PublicKey, keys["2024-06"])
+		assert.Equal(t, &previousKey.PublicKey, keys["2024-01"])
+		assert.IsType(t, &ecdsa.PublicKey{}, keys["2023-06"])
+	})
+
+	t.Run("should prefer the public key file", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, TokenMinRSABits)
+		require.NoError(t, err)
+
+		token := Token{
+			Algorithm:      TokenRS256,
+			PrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem"),
+			PublicKeyFile:  writeKey(t, "RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&key.PublicKey)),
+		}
+
+		keys, err := token.VerifyingKeys()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]crypto.PublicKey{"": &key.PublicKey}, keys)
+	})
+
+	t.Run("should return an error for invalid keys", func(t *testing.T) {
+		token := Token{
+			Secret:           "current.secret",
+			KeyID:            "2024-06",
+			VerificationKeys: []TokenKey{{KeyID: "2024-06", Secret: "previous.secret"}},
+		}
+		_, err := token.VerifyingKeys()
+		assert.EqualError(t, err, `duplicate token key id "2024-06"`)
+
+		token = Token{Algorithm: TokenEdDSA, VerificationKeys: []TokenKey{{KeyID: "2024-01", Secret: "previous.secret"}}}
+		_, err = token.VerifyingKeys()
+		assert.EqualError(t, err, `token key "2024-01": public key file is required with algorithm EdDSA`)
+
+		_, err = Token{Algorithm: TokenEdDSA}.VerifyingKeys()
+		assert.EqualError(t, err, "no token verifying key")
+	})
+}
+
+func writePKCS8Key(t *testing.T, key crypto.PrivateKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return writeKey(t, "PRIVATE KEY", der)
+}
+
+func writeKey(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "token.pem")
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)
+	require.NoError(t, err)
+
+	return path
+}
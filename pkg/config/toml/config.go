@@ -1,66 +1,125 @@
 package toml
 
 import (
+	"errors"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 
 	"github.com/ribeirohugo/go_config/v2/pkg/config"
 )
 
+// decodeErrorPattern matches the line and key prefix of toml errors, as in `toml: line 2 (last key "server.port"): `.
+// Type errors are not returned as toml.ParseError, and only hold their location in it.
+var decodeErrorPattern = regexp.MustCompile(`^toml: line (\d+)(?: \(last key "([^"]*)"\))?: `)
+
 // Load loads configurations from a given toml file path.
 func Load(filePath string) (config.Config, error) {
+	return load(filePath, decode)
+}
+
+// LoadStrict loads configurations from a given toml file path, returning an error for unknown keys.
+func LoadStrict(filePath string) (config.Config, error) {
+	return load(filePath, decodeStrict)
+}
+
+// LoadContent loads configurations from a given toml bytes content.
+func LoadContent(content []byte) (config.Config, error) {
+	return decode("", content)
+}
+
+// LoadContentStrict loads configurations from a given toml bytes content, returning an error for unknown keys.
+func LoadContentStrict(content []byte) (config.Config, error) {
+	return decodeStrict("", content)
+}
+
+func load(filePath string, decode func(string, []byte) (config.Config, error)) (config.Config, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return config.Config{}, err
+		return config.Config{}, &config.Error{Source: filePath, Err: err}
 	}
 
 	bytes, err := io.ReadAll(file)
 	if err != nil {
-		return config.Config{}, err
+		return config.Config{}, &config.Error{Source: filePath, Err: err}
 	}
 	_ = file.Close()
 
-	return LoadContent(bytes)
+	return decode(filePath, bytes)
 }
 
-// LoadContent loads configurations from a given toml bytes content.
-func LoadContent(content []byte) (config.Config, error) {
-	cfg := config.Config{
-		MySql: config.Database{
-			Port:           config.DefaultMySQLPort,
-			MigrationsPath: config.DefaultMigrationsMysql,
-		},
-		MongoDb: config.Database{
-			Port:           config.DefaultMongoPort,
-			MigrationsPath: config.DefaultMigrationsMongo,
-		},
-		Postgres: config.Database{
-			Port:           config.DefaultPostgresPort,
-			MigrationsPath: config.DefaultMigrationsPostgres,
-		},
-		Token: config.Token{
-			MaxAge: config.DefaultSessionMaxAge,
-		},
-		Loki: config.ExternalService{
-			Host: config.DefaultLokiHost,
-		},
-		Tempo: config.ExternalService{
-			Host: config.DefaultTempoHost,
-		},
-		Jaeger: config.ExternalService{
-			Host: config.DefaultJaegerHost,
-		},
-		Redis: config.ExternalService{
-			Host: config.DefaultRedisHost,
-		},
-	}
+func decode(source string, content []byte) (config.Config, error) {
+	cfg := config.Default()
 
 	err := toml.Unmarshal(content, &cfg)
 	if err != nil {
-		return config.Config{}, err
+		return config.Config{}, decodeError(source, err)
+	}
+
+	return cfg.ApplyURLs(source)
+}
+
+func decodeStrict(source string, content []byte) (config.Config, error) {
+	cfg := config.Default()
+
+	metadata, err := toml.Decode(string(content), &cfg)
+	if err != nil {
+		return config.Config{}, decodeError(source, err)
+	}
+
+	var errs []error
+	for _, key := range undecodedKeys(metadata) {
+		errs = append(errs, &config.Error{Source: source, Key: key, Err: config.ErrUnknownKey})
+	}
+	if len(errs) > 0 {
+		return config.Config{}, errors.Join(errs...)
+	}
+
+	return cfg.ApplyURLs(source)
+}
+
+// decodeError wraps a toml error into a config.Error, with the error line, column and key when available.
+// The location prefix of the toml message is left out, as it is given by the config.Error fields.
+func decodeError(source string, err error) error {
+	cfgErr := &config.Error{Source: source, Err: err}
+
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) {
+		cfgErr.Key = parseErr.LastKey
+		cfgErr.Line = parseErr.Position.Line
+		cfgErr.Column = parseErr.Position.Col
+		cfgErr.Message = "toml: " + parseErr.Message
+		return cfgErr
+	}
+
+	match := decodeErrorPattern.FindStringSubmatch(err.Error())
+	if match != nil {
+		cfgErr.Line, _ = strconv.Atoi(match[1])
+		cfgErr.Key = match[2]
+		cfgErr.Message = "toml: " + strings.TrimPrefix(err.Error(), match[0])
+	}
+
+	return cfgErr
+}
+
+// undecodedKeys returns undecoded key paths, skipping keys nested in an undecoded table.
+func undecodedKeys(metadata toml.MetaData) []string {
+	undecoded := metadata.Undecoded()
+	skipped := make(map[string]bool, len(undecoded))
+	keys := make([]string, 0, len(undecoded))
+
+	for _, key := range undecoded {
+		path := key.String()
+		skipped[path] = true
+		if len(key) > 1 && skipped[key[:len(key)-1].String()] {
+			continue
+		}
+		keys = append(keys, path)
 	}
 
-	return cfg, nil
+	return keys
 }
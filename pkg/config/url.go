@@ -0,0 +1,325 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	postgresScheme    = "postgres"
+	postgresqlScheme  = "postgresql"
+	mysqlScheme       = "mysql"
+	mysqlTCPProtocol  = "tcp"
+	mysqlUnixProtocol = "unix"
+	mysqlDefaultHost  = "127.0.0.1"
+	postgresSSLMode   = "sslmode"
+	postgresHost      = "host"
+	clickhouseScheme  = "clickhouse"
+	sqlserverScheme   = "sqlserver"
+	sqlserverDatabase = "database"
+	sqliteScheme      = "file"
+	sqlitePragma      = "_pragma"
+)
+
+// ParseDatabaseURL parses a postgres://, mysql://, mongodb://, sqlserver:// or clickhouse:// connection URL,
+// a go-sql-driver MySQL DSN or a file: SQLite DSN, into database connection fields.
+// Driver is set from the URL scheme.
+func ParseDatabaseURL(rawURL string) (Database, error) {
+	scheme, _, ok := strings.Cut(rawURL, "://")
+	switch {
+	case ok && (scheme == postgresScheme || scheme == postgresqlScheme):
+		return ParsePostgresURL(rawURL)
+	case ok && (scheme == mongodbScheme || scheme == mongodbSRVScheme):
+		return ParseMongodbURI(rawURL)
+	case ok && scheme == sqlserverScheme:
+		return ParseSQLServerURL(rawURL)
+	case ok && scheme == clickhouseScheme:
+		return ParseClickHouseURL(rawURL)
+	case strings.HasPrefix(rawURL, sqliteScheme+":"):
+		return ParseSQLiteDSN(rawURL)
+	case (ok && scheme == mysqlScheme) || (!ok && isMysqlDSN(rawURL)):
+		return ParseMysqlDSN(rawURL)
+	case ok:
+		return Database{}, fmt.Errorf("unsupported database url scheme %q", scheme)
+	default:
+		return Database{}, errors.New("invalid database url, must be a connection url or a mysql or sqlite dsn")
+	}
+}
+
+// isMysqlDSN reports whether a URL without scheme is a go-sql-driver DSN: its address, as tcp(host:port)
+// or unix(/path), follows user credentials or starts it, or it holds only a database, as in /database.
+func isMysqlDSN(rawURL string) bool {
+	address := rawURL[strings.LastIndex(rawURL, "@")+1:]
+	return strings.HasPrefix(address, mysqlTCPProtocol+"(") || strings.HasPrefix(address, mysqlUnixProtocol+"(") ||
+		strings.HasPrefix(address, "/")
+}
+
+// ParsePostgresURL parses a postgres:// or postgresql:// URL, as held by DATABASE_URL, into database fields.
+// The port defaults to DefaultPostgresPort, and query parameters are kept in Options,
+// except for a host parameter holding a unix socket path, as in ?host=/var/run/postgresql, kept in Socket.
+func ParsePostgresURL(rawURL string) (Database, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Database{}, fmt.Errorf("invalid postgres url: %w", err)
+	}
+	if u.Scheme != postgresScheme && u.Scheme != postgresqlScheme {
+		return Database{}, fmt.Errorf("invalid postgres url scheme %q, must be postgres or postgresql", u.Scheme)
+	}
+
+	return databaseFromURL(DriverPostgres, DefaultPostgresPort, u)
+}
+
+// ParseMysqlDSN parses a go-sql-driver DSN, as user:password@tcp(host:port)/database?options
+// or user:password@unix(/path)/database, or a mysql:// URL, into database fields.
+// The port defaults to DefaultMySQLPort, and DSNs without address, as /database, use the driver default one,
+// tcp(127.0.0.1:3306).
+func ParseMysqlDSN(dsn string) (Database, error) {
+	if strings.HasPrefix(dsn, mysqlScheme+"://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return Database{}, fmt.Errorf("invalid mysql url: %w", err)
+		}
+		return databaseFromURL(DriverMySQL, DefaultMySQLPort, u)
+	}
+
+	rest, options, _ := strings.Cut(dsn, "?")
+	slash := strings.LastIndex(rest, "/")
+	if slash < 0 {
+		return Database{}, errors.New("invalid mysql dsn: missing database")
+	}
+	rest, db := rest[:slash], rest[slash+1:]
+
+	database := Database{
+		Driver:  DriverMySQL,
+		Db:      db,
+		Options: options,
+	}
+
+	if at := strings.LastIndex(rest, "@"); at >= 0 {
+		database.User, database.Password, _ = strings.Cut(rest[:at], ":")
+		rest = rest[at+1:]
+	}
+
+	if rest == "" {
+		rest = mysqlTCPProtocol + "(" + mysqlDefaultHost + ")"
+	}
+
+	protocol, address, ok := strings.Cut(strings.TrimSuffix(rest, ")"), "(")
+	switch {
+	case ok && protocol == mysqlUnixProtocol:
+		database.Socket = address
+		return database, nil
+	case !ok || protocol != mysqlTCPProtocol:
+		return Database{}, fmt.Errorf("invalid mysql dsn protocol %q, must be tcp or unix", protocol)
+	}
+
+	database.Port = DefaultMySQLPort
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		database.Host = address
+		return database, nil
+	}
+	database.Host = host
+	database.Port, err = strconv.Atoi(port)
+	if err != nil {
+		return Database{}, fmt.Errorf("invalid mysql dsn port %q", port)
+	}
+
+	return database, nil
+}
+
+// ParseSQLServerURL parses a sqlserver:// URL into database fields, with the database name taken from
+// the database parameter. The port defaults to DefaultSQLServerPort, and other parameters are kept in Options.
+func ParseSQLServerURL(rawURL string) (Database, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Database{}, fmt.Errorf("invalid sqlserver url: %w", err)
+	}
+	if u.Scheme != sqlserverScheme {
+		return Database{}, fmt.Errorf("invalid sqlserver url scheme %q, must be sqlserver", u.Scheme)
+	}
+
+	options := u.Query()
+	db := options.Get(sqlserverDatabase)
+	options.Del(sqlserverDatabase)
+	u.RawQuery = options.Encode()
+
+	database, err := databaseFromURL(DriverSQLServer, DefaultSQLServerPort, u)
+	if err != nil {
+		return Database{}, err
+	}
+	database.Db = db
+	return database, nil
+}
+
+// ParseClickHouseURL parses a clickhouse:// URL into database fields.
+// The port defaults to DefaultClickHousePort, and query parameters are kept in Options.
+func ParseClickHouseURL(rawURL string) (Database, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Database{}, fmt.Errorf("invalid clickhouse url: %w", err)
+	}
+	if u.Scheme != clickhouseScheme {
+		return Database{}, fmt.Errorf("invalid clickhouse url scheme %q, must be clickhouse", u.Scheme)
+	}
+
+	return databaseFromURL(DriverClickHouse, DefaultClickHousePort, u)
+}
+
+// ParseSQLiteDSN parses a file: SQLite DSN, as file:path?_pragma=journal_mode(WAL), into database fields.
+// Pragmas are kept in Pragmas as name=value, and other parameters in Options.
+func ParseSQLiteDSN(dsn string) (Database, error) {
+	path, rawOptions, _ := strings.Cut(strings.TrimPrefix(dsn, sqliteScheme+":"), "?")
+	if path == "" {
+		return Database{}, errors.New("invalid sqlite dsn: missing path")
+	}
+
+	database := Database{
+		Driver: DriverSQLite,
+		Path:   path,
+	}
+
+	var options []string
+	for _, option := range strings.Split(rawOptions, "&") {
+		pragma, ok := strings.CutPrefix(option, sqlitePragma+"=")
+		switch {
+		case ok:
+			pragma, err := url.QueryUnescape(pragma)
+			if err != nil {
+				return Database{}, fmt.Errorf("invalid sqlite dsn pragma: %w", err)
+			}
+			if name, value, ok := strings.Cut(strings.TrimSuffix(pragma, ")"), "("); ok {
+				pragma = name + "=" + value
+			}
+			database.Pragmas = append(database.Pragmas, pragma)
+		case option != "":
+			options = append(options, option)
+		}
+	}
+	database.Options = strings.Join(options, "&")
+
+	return database, nil
+}
+
+func databaseFromURL(driver string, defaultPort int, u *url.URL) (Database, error) {
+	database := Database{
+		Driver:  driver,
+		Host:    u.Hostname(),
+		Port:    defaultPort,
+		Db:      strings.TrimPrefix(u.Path, "/"),
+		Options: u.RawQuery,
+	}
+
+	options := u.Query()
+	if socket := options.Get(postgresHost); driver == DriverPostgres && strings.HasPrefix(socket, "/") {
+		options.Del(postgresHost)
+		database.Socket = socket
+		database.Options = options.Encode()
+	}
+	if database.Host == "" && database.Socket == "" {
+		return Database{}, fmt.Errorf("invalid %s url: missing host", driver)
+	}
+	if port := u.Port(); port != "" {
+		var err error
+		database.Port, err = strconv.Atoi(port)
+		if err != nil {
+			return Database{}, fmt.Errorf("invalid %s url port %q", driver, port)
+		}
+	}
+	if u.User != nil {
+		database.User = u.User.Username()
+		database.Password, _ = u.User.Password()
+	}
+
+	return database, nil
+}
+
+// ApplyURL returns the database with connection fields parsed from URL, when set.
+// URLs must match the given driver, or the database driver when driver is empty.
+// Driver is set from the URL when both are empty, as for named databases.
+func (d Database) ApplyURL(driver string) (Database, error) {
+	if d.URL == "" {
+		return d, nil
+	}
+
+	parsed, err := ParseDatabaseURL(d.URL)
+	if err != nil {
+		return Database{}, err
+	}
+
+	if driver == "" {
+		driver = d.Driver
+	}
+	if driver == "" {
+		d.Driver = parsed.Driver
+	} else if parsed.Driver != driver {
+		return Database{}, fmt.Errorf("%s url given to a %s database", parsed.Driver, driver)
+	}
+
+	d.Host = parsed.Host
+	d.Port = parsed.Port
+	d.Socket = parsed.Socket
+	d.Hosts = parsed.Hosts
+	d.SRV = parsed.SRV
+	d.User = parsed.User
+	d.Password = parsed.Password
+	d.Db = parsed.Db
+	d.ReplicaSet = parsed.ReplicaSet
+	d.Options = parsed.Options
+	d.Path = parsed.Path
+	d.Pragmas = parsed.Pragmas
+
+	return d, nil
+}
+
+// ApplyURLs returns the configuration with the url of each database block applied, as in ApplyURL.
+// Named databases and services are also completed with their defaults, as in WithDefaults.
+// Errors are returned as *Error, holding the given source and the url key path.
+func (c Config) ApplyURLs(source string) (Config, error) {
+	var err error
+
+	blocks := []struct {
+		key    string
+		driver string
+		db     *Database
+	}{
+		{"mongodb", DriverMongoDB, &c.MongoDb},
+		{"mysql", DriverMySQL, &c.MySql},
+		{"postgres", DriverPostgres, &c.Postgres},
+		{"sqlite", DriverSQLite, &c.SQLite},
+		{"sqlserver", DriverSQLServer, &c.SQLServer},
+		{"clickhouse", DriverClickHouse, &c.ClickHouse},
+	}
+	for _, block := range blocks {
+		*block.db, err = block.db.ApplyURL(block.driver)
+		if err != nil {
+			return Config{}, &Error{Source: source, Key: block.key + ".url", Err: err}
+		}
+	}
+
+	if c.Databases != nil {
+		databases := make(Databases, len(c.Databases))
+		for name, db := range c.Databases {
+			db, err = db.ApplyURL("")
+			if err != nil {
+				return Config{}, &Error{Source: source, Key: "databases." + name + ".url", Err: err}
+			}
+			databases[name] = db.WithDefaults()
+		}
+		c.Databases = databases
+	}
+
+	if c.Services != nil {
+		services := make(Services, len(c.Services))
+		for name, service := range c.Services {
+			services[name] = service.WithDefaults()
+		}
+		c.Services = services
+	}
+
+	return c, nil
+}
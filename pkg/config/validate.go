@@ -0,0 +1,449 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const maxPort = 65535
+
+// FieldError describes a single invalid configuration field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error returns the field path followed by its validation message.
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors holds every invalid field found while validating a Config.
+type ValidationErrors []FieldError
+
+// Error returns all validation messages, one per line.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Validate checks required fields and value ranges.
+// It returns ValidationErrors when one or more fields are invalid.
+func (c Config) Validate() error {
+	var errs ValidationErrors
+
+	if c.Server.Host == "" {
+		errs = append(errs, FieldError{Field: "server.host", Message: "is required"})
+	}
+	if c.Server.Port == 0 {
+		errs = append(errs, FieldError{Field: "server.port", Message: "is required"})
+	} else if !validPort(c.Server.Port) {
+		errs = append(errs, FieldError{Field: "server.port", Message: portMessage(c.Server.Port)})
+	}
+
+	errs = append(errs, c.Server.validate()...)
+
+	errs = append(errs, c.Token.validate("token")...)
+
+	errs = append(errs, c.MongoDb.validate("mongodb")...)
+	errs = append(errs, c.MySql.validate("mysql")...)
+	errs = append(errs, c.Postgres.validate("postgres")...)
+	errs = append(errs, c.SQLite.validate("sqlite")...)
+	errs = append(errs, c.SQLServer.validate("sqlserver")...)
+	errs = append(errs, c.ClickHouse.validate("clickhouse")...)
+	for _, name := range slices.Sorted(maps.Keys(c.Databases)) {
+		errs = append(errs, c.Databases[name].validateNamed("databases."+name)...)
+	}
+
+	errs = append(errs, c.Audit.validate("audit")...)
+	errs = append(errs, c.Jaeger.validate("jaeger")...)
+	errs = append(errs, c.Loki.validate("loki")...)
+	errs = append(errs, c.Tempo.validate("tempo")...)
+	errs = append(errs, c.Prometheus.validate("prometheus")...)
+	errs = append(errs, c.Redis.validate("redis")...)
+	errs = append(errs, c.OTel.validate("otel")...)
+	errs = append(errs, c.Log.validate("log")...)
+	for _, name := range slices.Sorted(maps.Keys(c.Services)) {
+		errs = append(errs, c.Services[name].validate("services."+name)...)
+	}
+	errs = append(errs, c.Kafka.validate("kafka")...)
+	errs = append(errs, c.NATS.validate("nats")...)
+	errs = append(errs, c.RabbitMQ.validate("rabbitmq")...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (s Server) validate() []FieldError {
+	var errs []FieldError
+
+	switch {
+	case s.TLSCertFile != "" && s.TLSKeyFile == "":
+		errs = append(errs, FieldError{Field: "server.tls_key_file", Message: "is required when tls_cert_file is set"})
+	case s.TLSKeyFile != "" && s.TLSCertFile == "":
+		errs = append(errs, FieldError{Field: "server.tls_cert_file", Message: "is required when tls_key_file is set"})
+	}
+
+	if _, err := s.tlsMinVersion(); err != nil {
+		errs = append(errs, FieldError{Field: "server.tls_min_version", Message: err.Error()})
+	}
+
+	if s.CORS.AllowCredentials && slices.Contains(s.AllowedOrigins, wildcard) {
+		errs = append(errs, FieldError{Field: "server.cors.allow_credentials", Message: "must not be set when any origin is allowed"})
+	}
+
+	return errs
+}
+
+func (d Database) validate(prefix string) []FieldError {
+	var errs []FieldError
+
+	if d.Port != 0 && !validPort(d.Port) {
+		errs = append(errs, FieldError{Field: prefix + ".port", Message: portMessage(d.Port)})
+	}
+
+	negatives := []struct {
+		field string
+		value int64
+	}{
+		{"max_open_conns", int64(d.MaxOpenConns)},
+		{"max_idle_conns", int64(d.MaxIdleConns)},
+		{"conn_max_lifetime", int64(d.ConnMaxLifetime)},
+		{"conn_max_idle_time", int64(d.ConnMaxIdleTime)},
+	}
+	for _, negative := range negatives {
+		if negative.value < 0 {
+			errs = append(errs, FieldError{Field: prefix + "." + negative.field, Message: "must not be negative"})
+		}
+	}
+
+	if d.Socket != "" && !strings.HasPrefix(d.Socket, "/") {
+		errs = append(errs, FieldError{Field: prefix + ".socket", Message: "must be an absolute path"})
+	}
+	if d.SRV && len(d.Hosts) > 0 {
+		errs = append(errs, FieldError{Field: prefix + ".hosts", Message: "must not be set when srv is enabled"})
+	}
+	if _, err := url.ParseQuery(d.Options); err != nil {
+		errs = append(errs, FieldError{Field: prefix + ".options", Message: "invalid query string"})
+	}
+
+	for i, replica := range d.Replicas {
+		replicaPrefix := fmt.Sprintf("%s.replicas[%d]", prefix, i)
+		if replica.Host == "" {
+			errs = append(errs, FieldError{Field: replicaPrefix + ".host", Message: "is required"})
+		}
+		if replica.Port != 0 && !validPort(replica.Port) {
+			errs = append(errs, FieldError{Field: replicaPrefix + ".port", Message: portMessage(replica.Port)})
+		}
+	}
+
+	return errs
+}
+
+func (d Database) validateNamed(prefix string) []FieldError {
+	var errs []FieldError
+
+	if d.Driver == "" {
+		errs = append(errs, FieldError{Field: prefix + ".driver", Message: "is required"})
+	} else if _, ok := driverPorts[d.Driver]; !ok {
+		errs = append(errs, FieldError{Field: prefix + ".driver", Message: fmt.Sprintf("unsupported driver %q", d.Driver)})
+	}
+
+	return append(errs, d.validate(prefix)...)
+}
+
+func (s ExternalService) validate(prefix string) []FieldError {
+	var errs []FieldError
+
+	if s.Enabled && s.Host == "" {
+		errs = append(errs, FieldError{Field: prefix + ".host", Message: "is required when service is enabled"})
+	}
+	if s.Timeout < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".timeout", Message: "must not be negative"})
+	}
+	if s.Retries < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".retries", Message: "must not be negative"})
+	}
+	errs = append(errs, validateTLSKeyPair(prefix, s.TLSCertFile, s.TLSKeyFile)...)
+
+	return errs
+}
+
+func (l Loki) validate(prefix string) []FieldError {
+	var errs []FieldError
+
+	if l.Enabled && l.Host == "" {
+		errs = append(errs, FieldError{Field: prefix + ".host", Message: "is required when service is enabled"})
+	}
+	for _, name := range slices.Sorted(maps.Keys(l.Labels)) {
+		if !validLokiLabel(name) {
+			message := "invalid label name, must contain only letters, digits and underscores"
+			errs = append(errs, FieldError{Field: prefix + ".labels." + name, Message: message})
+		}
+	}
+	if l.BatchSize < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".batch_size", Message: "must not be negative"})
+	}
+	if l.BatchWait < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".batch_wait", Message: "must not be negative"})
+	}
+	if l.Timeout < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".timeout", Message: "must not be negative"})
+	}
+
+	return errs
+}
+
+func (p Prometheus) validate(prefix string) []FieldError {
+	var errs []FieldError
+
+	if p.Port != 0 && !validPort(p.Port) {
+		errs = append(errs, FieldError{Field: prefix + ".port", Message: portMessage(p.Port)})
+	}
+	if p.Path != "" && !strings.HasPrefix(p.Path, "/") {
+		errs = append(errs, FieldError{Field: prefix + ".path", Message: "must start with /"})
+	}
+	if p.Namespace != "" && !validMetricName(p.Namespace) {
+		message := "invalid namespace, must contain only letters, digits and underscores"
+		errs = append(errs, FieldError{Field: prefix + ".namespace", Message: message})
+	}
+	if p.Subsystem != "" && !validMetricName(p.Subsystem) {
+		message := "invalid subsystem, must contain only letters, digits and underscores"
+		errs = append(errs, FieldError{Field: prefix + ".subsystem", Message: message})
+	}
+	if p.BasicAuth.Username != "" && p.BasicAuth.Password == "" {
+		errs = append(errs, FieldError{Field: prefix + ".basic_auth.password", Message: "is required with a username"})
+	}
+	if u, err := url.Parse(p.PushgatewayURL); p.PushgatewayURL != "" && (err != nil || u.Host == "") {
+		message := "must be a url, as http://localhost:9091"
+		errs = append(errs, FieldError{Field: prefix + ".pushgateway_url", Message: message})
+	}
+
+	return errs
+}
+
+func (r Redis) validate(prefix string) []FieldError {
+	var errs []FieldError
+
+	if r.Enabled && r.Host == "" && len(r.SentinelAddresses) == 0 && len(r.ClusterAddresses) == 0 {
+		errs = append(errs, FieldError{Field: prefix + ".host", Message: "is required when service is enabled"})
+	}
+	if r.DB < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".db", Message: "must not be negative"})
+	}
+	if len(r.SentinelAddresses) > 0 && r.MasterName == "" {
+		errs = append(errs, FieldError{Field: prefix + ".master_name", Message: "is required with sentinel addresses"})
+	}
+
+	return errs
+}
+
+func (o OTel) validate(prefix string) []FieldError {
+	var errs []FieldError
+
+	if o.SampleRatio < 0 || o.SampleRatio > 1 {
+		errs = append(errs, FieldError{Field: prefix + ".sample_ratio", Message: "must be between 0 and 1"})
+	}
+	if o.MetricsInterval < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".metrics_interval", Message: "must not be negative"})
+	}
+	if o.Timeout < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".timeout", Message: "must not be negative"})
+	}
+	errs = append(errs, validateOTLP(prefix, o.Endpoint, o.Protocol)...)
+
+	signals := []struct {
+		name     string
+		exporter OTelExporter
+	}{
+		{"traces", o.Traces},
+		{"metrics", o.Metrics},
+		{"logs", o.Logs},
+	}
+	for _, signal := range signals {
+		signalPrefix := prefix + "." + signal.name
+		if signal.exporter.Exporter != "" && !slices.Contains(otelExporters, signal.exporter.Exporter) {
+			message := fmt.Sprintf("unsupported exporter %q, must be otlp, console or none", signal.exporter.Exporter)
+			errs = append(errs, FieldError{Field: signalPrefix + ".exporter", Message: message})
+		}
+		errs = append(errs, validateOTLP(signalPrefix, signal.exporter.Endpoint, signal.exporter.Protocol)...)
+	}
+
+	return errs
+}
+
+func validateOTLP(prefix string, endpoint string, protocol string) []FieldError {
+	var errs []FieldError
+
+	if u, err := url.Parse(endpoint); endpoint != "" && (err != nil || u.Host == "") {
+		errs = append(errs, FieldError{Field: prefix + ".endpoint", Message: "must be a url, as http://localhost:4318"})
+	}
+	if protocol != "" && !slices.Contains(otelProtocols, protocol) {
+		message := fmt.Sprintf("unsupported protocol %q, must be grpc, http/protobuf or http/json", protocol)
+		errs = append(errs, FieldError{Field: prefix + ".protocol", Message: message})
+	}
+
+	return errs
+}
+
+func (l Logging) validate(prefix string) []FieldError {
+	var errs []FieldError
+
+	if _, err := parseLogLevel(l.Level); err != nil {
+		errs = append(errs, FieldError{Field: prefix + ".level", Message: err.Error()})
+	}
+	if l.Format != "" && l.Format != LogFormatText && l.Format != LogFormatJSON {
+		message := fmt.Sprintf("unsupported format %q, must be text or json", l.Format)
+		errs = append(errs, FieldError{Field: prefix + ".format", Message: message})
+	}
+	for _, pkg := range slices.Sorted(maps.Keys(l.Levels)) {
+		if _, err := parseLogLevel(l.Levels[pkg]); err != nil {
+			errs = append(errs, FieldError{Field: prefix + ".levels." + pkg, Message: err.Error()})
+		}
+	}
+
+	return errs
+}
+
+func (t Token) validate(prefix string) []FieldError {
+	var errs []FieldError
+
+	if t.MaxAge < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".max_age", Message: "must not be negative"})
+	}
+	if t.RefreshMaxAge < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".refresh_max_age", Message: "must not be negative"})
+	}
+
+	algorithm, err := t.algorithm()
+	if err != nil {
+		return append(errs, FieldError{Field: prefix + ".algorithm", Message: err.Error()})
+	}
+	switch {
+	case t.Symmetric() && t.Secret == "" && t.configured():
+		errs = append(errs, FieldError{Field: prefix + ".secret", Message: "is required with algorithm " + algorithm})
+	case !t.Symmetric() && t.PrivateKeyFile == "" && t.PublicKeyFile == "":
+		message := fmt.Sprintf("is required with algorithm %s, unless public_key_file is set", algorithm)
+		errs = append(errs, FieldError{Field: prefix + ".private_key_file", Message: message})
+	}
+	if !t.Symmetric() && t.PrivateKeyFile != "" {
+		if _, err := loadTokenPrivateKey(algorithm, t.PrivateKeyFile); err != nil {
+			errs = append(errs, FieldError{Field: prefix + ".private_key_file", Message: err.Error()})
+		}
+	}
+	if !t.Symmetric() && t.PublicKeyFile != "" {
+		if _, err := loadTokenPublicKey(algorithm, t.PublicKeyFile); err != nil {
+			errs = append(errs, FieldError{Field: prefix + ".public_key_file", Message: err.Error()})
+		}
+	}
+
+	keyIDs := map[string]bool{t.KeyID: true}
+	for i, key := range t.VerificationKeys {
+		keyPrefix := fmt.Sprintf("%s.verification_keys[%d]", prefix, i)
+		switch {
+		case key.KeyID == "":
+			errs = append(errs, FieldError{Field: keyPrefix + ".key_id", Message: "is required"})
+		case keyIDs[key.KeyID]:
+			errs = append(errs, FieldError{Field: keyPrefix + ".key_id", Message: fmt.Sprintf("duplicate key id %q", key.KeyID)})
+		}
+		keyIDs[key.KeyID] = true
+
+		switch {
+		case t.Symmetric() && key.Secret == "":
+			errs = append(errs, FieldError{Field: keyPrefix + ".secret", Message: "is required with algorithm " + algorithm})
+		case !t.Symmetric() && key.PublicKeyFile == "":
+			errs = append(errs, FieldError{Field: keyPrefix + ".public_key_file", Message: "is required with algorithm " + algorithm})
+		case !t.Symmetric():
+			if _, err := loadTokenPublicKey(algorithm, key.PublicKeyFile); err != nil {
+				errs = append(errs, FieldError{Field: keyPrefix + ".public_key_file", Message: err.Error()})
+			}
+		}
+	}
+
+	return errs
+}
+
+func (k Kafka) validate(prefix string) []FieldError {
+	var errs []FieldError
+
+	for i, broker := range k.Brokers {
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			errs = append(errs, FieldError{Field: fmt.Sprintf("%s.brokers[%d]", prefix, i), Message: "must be host:port"})
+		}
+	}
+	if k.DialTimeout < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".dial_timeout", Message: "must not be negative"})
+	}
+
+	if k.SASLEnabled() && !validSASLMechanism(k.SASL.Mechanism) {
+		message := fmt.Sprintf("unsupported mechanism %q, must be PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512", k.SASL.Mechanism)
+		errs = append(errs, FieldError{Field: prefix + ".sasl.mechanism", Message: message})
+	}
+	if k.SASLEnabled() && k.SASL.Password == "" {
+		errs = append(errs, FieldError{Field: prefix + ".sasl.password", Message: "is required with a sasl username"})
+	}
+
+	errs = append(errs, validateTLSKeyPair(prefix, k.TLSCertFile, k.TLSKeyFile)...)
+
+	return errs
+}
+
+// validateTLSKeyPair reports a client certificate set without its key, or a key without its certificate.
+func validateTLSKeyPair(prefix string, certFile string, keyFile string) []FieldError {
+	switch {
+	case certFile != "" && keyFile == "":
+		return []FieldError{{Field: prefix + ".tls_key_file", Message: "is required when tls_cert_file is set"}}
+	case keyFile != "" && certFile == "":
+		return []FieldError{{Field: prefix + ".tls_cert_file", Message: "is required when tls_key_file is set"}}
+	}
+	return nil
+}
+
+func (n NATS) validate(prefix string) []FieldError {
+	var errs []FieldError
+
+	for i, rawURL := range n.URLs {
+		if u, err := url.Parse(rawURL); err != nil || u.Host == "" {
+			field := fmt.Sprintf("%s.urls[%d]", prefix, i)
+			errs = append(errs, FieldError{Field: field, Message: "must be a url, as nats://host:4222"})
+		}
+	}
+	if n.ConnectTimeout < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".connect_timeout", Message: "must not be negative"})
+	}
+
+	return errs
+}
+
+func (r RabbitMQ) validate(prefix string) []FieldError {
+	var errs []FieldError
+
+	if r.Enabled && r.Host == "" {
+		errs = append(errs, FieldError{Field: prefix + ".host", Message: "is required when service is enabled"})
+	}
+	if r.Port != 0 && !validPort(r.Port) {
+		errs = append(errs, FieldError{Field: prefix + ".port", Message: portMessage(r.Port)})
+	}
+	if r.Heartbeat < 0 {
+		errs = append(errs, FieldError{Field: prefix + ".heartbeat", Message: "must not be negative"})
+	}
+
+	return errs
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= maxPort
+}
+
+func portMessage(port int) string {
+	return fmt.Sprintf("invalid port %d, must be between 1 and %d", port, maxPort)
+}
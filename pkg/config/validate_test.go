@@ -0,0 +1,512 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	t.Run("should return no error for a valid config", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Postgres: Database{
+				Host: serverHost,
+				Port: DefaultPostgresPort,
+			},
+			Audit: ExternalService{
+				Enabled: true,
+				Host:    "audit.domain",
+			},
+		}
+
+		err := cfg.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return every invalid field", func(t *testing.T) {
+		cfg := Config{
+			MySql: Database{
+				Port: 70000,
+			},
+			Redis: Redis{
+				Enabled: true,
+			},
+		}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+
+		var validationErrs ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Equal(t, ValidationErrors{
+			{Field: "server.host", Message: "is required"},
+			{Field: "server.port", Message: "is required"},
+			{Field: "mysql.port", Message: "invalid port 70000, must be between 1 and 65535"},
+			{Field: "redis.host", Message: "is required when service is enabled"},
+		}, validationErrs)
+	})
+
+	t.Run("should return an error for negative pool settings", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Postgres: Database{
+				MaxIdleConns:    -1,
+				ConnMaxLifetime: Duration(-time.Second),
+			},
+		}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+
+		var validationErrs ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Equal(t, ValidationErrors{
+			{Field: "postgres.max_idle_conns", Message: "must not be negative"},
+			{Field: "postgres.conn_max_lifetime", Message: "must not be negative"},
+		}, validationErrs)
+	})
+
+	t.Run("should return an error for invalid replicas", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			MySql: Database{
+				Replicas: []Replica{{Host: serverHost}, {Port: 70000}},
+			},
+		}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+
+		var validationErrs ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Equal(t, ValidationErrors{
+			{Field: "mysql.replicas[1].host", Message: "is required"},
+			{Field: "mysql.replicas[1].port", Message: "invalid port 70000, must be between 1 and 65535"},
+		}, validationErrs)
+	})
+
+	t.Run("should return an error for invalid MongoDB addresses", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			MongoDb: Database{
+				Hosts:   []string{"mongo1.domain:27017"},
+				SRV:     true,
+				Options: "w=%zz",
+			},
+		}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+
+		var validationErrs ValidationErrors
+		require.ErrorAs(t, err, &validationErrs)
+		assert.Equal(t, ValidationErrors{
+			{Field: "mongodb.hosts", Message: "must not be set when srv is enabled"},
+			{Field: "mongodb.options", Message: "invalid query string"},
+		}, validationErrs)
+	})
+
+	t.Run("should return an error for a relative socket path", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Postgres: Database{
+				Socket: "run/postgresql",
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "postgres.socket: must be an absolute path")
+	})
+
+	t.Run("should return an error for an out of range server port", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: -1,
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "server.port: invalid port -1, must be between 1 and 65535")
+	})
+
+	t.Run("should return an error for a certificate without key", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host:        serverHost,
+				Port:        serverPort,
+				TLSCertFile: "server.crt",
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "server.tls_key_file: is required when tls_cert_file is set")
+	})
+
+	t.Run("should return an error for a key without certificate", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host:       serverHost,
+				Port:       serverPort,
+				TLSKeyFile: "server.key",
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "server.tls_cert_file: is required when tls_key_file is set")
+	})
+
+	t.Run("should return an error for an invalid TLS version", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host:          serverHost,
+				Port:          serverPort,
+				TLSMinVersion: "1.4",
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, `server.tls_min_version: invalid TLS version "1.4", must be 1.0, 1.1, 1.2 or 1.3`)
+	})
+
+	t.Run("should return an error for credentials with any origin", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host:           serverHost,
+				Port:           serverPort,
+				AllowedOrigins: []string{"*"},
+				CORS:           CORS{AllowCredentials: true},
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "server.cors.allow_credentials: must not be set when any origin is allowed")
+	})
+
+	t.Run("should return token errors", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Token: Token{
+				MaxAge:        Duration(-time.Hour),
+				RefreshMaxAge: Duration(-time.Hour),
+				KeyID:         "2024-06",
+				VerificationKeys: []TokenKey{
+					{Secret: "previous.secret"},
+					{KeyID: "2024-06", Secret: "current.secret"},
+					{KeyID: "2024-01"},
+				},
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "token.max_age: must not be negative\n"+
+			"token.refresh_max_age: must not be negative\n"+
+			"token.secret: is required with algorithm HS256\n"+
+			"token.verification_keys[0].key_id: is required\n"+
+			"token.verification_keys[1].key_id: duplicate key id \"2024-06\"\n"+
+			"token.verification_keys[2].secret: is required with algorithm HS256")
+	})
+
+	t.Run("should return asymmetric token errors", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Token: Token{
+				Algorithm:        TokenES256,
+				VerificationKeys: []TokenKey{{KeyID: "2024-01", Secret: "previous.secret"}},
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "token.private_key_file: is required with algorithm ES256, unless public_key_file is set\n"+
+			"token.verification_keys[0].public_key_file: is required with algorithm ES256")
+	})
+
+	t.Run("should return errors for invalid token key files", func(t *testing.T) {
+		shortKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		shortDER, err := x509.MarshalPKIXPublicKey(&shortKey.PublicKey)
+		require.NoError(t, err)
+		ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+		ecDER, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+		require.NoError(t, err)
+		missingFile := filepath.Join(t.TempDir(), "missing.pem")
+
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Token: Token{
+				Algorithm:        TokenRS256,
+				PrivateKeyFile:   missingFile,
+				PublicKeyFile:    writeKey(t, "PUBLIC KEY", shortDER),
+				VerificationKeys: []TokenKey{{KeyID: "2024-01", PublicKeyFile: writeKey(t, "PUBLIC KEY", ecDER)}},
+			},
+		}
+
+		err = cfg.Validate()
+		assert.EqualError(t, err, "token.private_key_file: open "+missingFile+": no such file or directory\n"+
+			"token.public_key_file: RSA key of 1024 bits, must be at least 2048\n"+
+			"token.verification_keys[0].public_key_file: ECDSA key of curve P-384 does not match algorithm RS256")
+
+		cfg.Token.Algorithm = TokenES256
+		cfg.Token.PrivateKeyFile = ""
+		cfg.Token.PublicKeyFile = ""
+		err = cfg.Validate()
+		assert.EqualError(t, err, "token.private_key_file: is required with algorithm ES256, unless public_key_file is set\n"+
+			"token.verification_keys[0].public_key_file: ECDSA key of curve P-384 does not match algorithm ES256")
+	})
+
+	t.Run("should require the secret of a configured HMAC algorithm only", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Token: Token{Algorithm: DefaultTokenAlgorithm},
+		}
+		assert.NoError(t, cfg.Validate())
+
+		cfg.Token.Algorithm = TokenHS512
+		assert.EqualError(t, cfg.Validate(), "token.secret: is required with algorithm HS512")
+
+		cfg.Token = Token{KeyID: "2024-06"}
+		assert.EqualError(t, cfg.Validate(), "token.secret: is required with algorithm HS256")
+	})
+
+	t.Run("should return an error for an unsupported token algorithm", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Token: Token{Algorithm: "none"},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "token.algorithm: unsupported token algorithm \"none\", must be one of "+
+			"HS256, HS384, HS512, RS256, RS384, RS512, PS256, PS384, PS512, ES256, ES384, ES512, EdDSA")
+	})
+
+	t.Run("should return external service errors", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Audit: ExternalService{
+				Host:        "audit.domain",
+				Timeout:     Duration(-time.Second),
+				Retries:     -1,
+				TLSCertFile: "client.crt",
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "audit.timeout: must not be negative\n"+
+			"audit.retries: must not be negative\n"+
+			"audit.tls_key_file: is required when tls_cert_file is set")
+	})
+
+	t.Run("should return Redis errors", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Redis: Redis{
+				Enabled:           true,
+				DB:                -1,
+				SentinelAddresses: []string{"sentinel:26379"},
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "redis.db: must not be negative\nredis.master_name: is required with sentinel addresses")
+	})
+
+	t.Run("should return Prometheus errors", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Prometheus: Prometheus{
+				Port:           70000,
+				Path:           "metrics",
+				Namespace:      "shop-api",
+				BasicAuth:      BasicAuth{Username: "prometheus.user"},
+				PushgatewayURL: "pushgateway.domain:9091",
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "prometheus.port: invalid port 70000, must be between 1 and 65535\n"+
+			"prometheus.path: must start with /\n"+
+			"prometheus.namespace: invalid namespace, must contain only letters, digits and underscores\n"+
+			"prometheus.basic_auth.password: is required with a username\n"+
+			"prometheus.pushgateway_url: must be a url, as http://localhost:9091")
+	})
+
+	t.Run("should return Loki errors", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Loki: Loki{
+				Enabled:   true,
+				Labels:    Attributes{"team": "payments", "service.name": "orders"},
+				BatchSize: -1,
+				BatchWait: Duration(-time.Second),
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "loki.host: is required when service is enabled\n"+
+			"loki.labels.service.name: invalid label name, must contain only letters, digits and underscores\n"+
+			"loki.batch_size: must not be negative\n"+
+			"loki.batch_wait: must not be negative")
+	})
+
+	t.Run("should return OpenTelemetry errors", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			OTel: OTel{
+				SampleRatio: 1.5,
+				Endpoint:    "otel.domain:4317",
+				Protocol:    "thrift",
+				Timeout:     Duration(-time.Second),
+				Traces:      OTelExporter{Exporter: "jaeger"},
+				Metrics:     OTelExporter{Endpoint: "http://otel.domain:4318/v1/metrics", Protocol: OTelProtocolHTTPJSON},
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "otel.sample_ratio: must be between 0 and 1\n"+
+			"otel.timeout: must not be negative\n"+
+			"otel.endpoint: must be a url, as http://localhost:4318\n"+
+			"otel.protocol: unsupported protocol \"thrift\", must be grpc, http/protobuf or http/json\n"+
+			"otel.traces.exporter: unsupported exporter \"jaeger\", must be otlp, console or none")
+	})
+
+	t.Run("should return logging errors", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Log: Logging{
+				Level:  "trace",
+				Format: "logfmt",
+				Levels: Attributes{"example.com/app/db": "debug", "example.com/app/http": "loud"},
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "log.level: invalid log level \"trace\", must be debug, info, warn or error\n"+
+			"log.format: unsupported format \"logfmt\", must be text or json\n"+
+			"log.levels.example.com/app/http: invalid log level \"loud\", must be debug, info, warn or error")
+	})
+
+	t.Run("should return message broker errors", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Kafka: Kafka{
+				Brokers:     []string{"kafka.domain:9092", "kafka.domain"},
+				SASL:        SASL{Mechanism: "GSSAPI", Username: "kafka.user"},
+				TLSCertFile: "client.crt",
+			},
+			NATS: NATS{
+				URLs: []string{"nats://nats.domain:4222", "nats.domain"},
+			},
+			RabbitMQ: RabbitMQ{
+				Enabled:   true,
+				Port:      70000,
+				Heartbeat: Duration(-time.Second),
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "kafka.brokers[1]: must be host:port\n"+
+			"kafka.sasl.mechanism: unsupported mechanism \"GSSAPI\", must be PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512\n"+
+			"kafka.sasl.password: is required with a sasl username\n"+
+			"kafka.tls_key_file: is required when tls_cert_file is set\n"+
+			"nats.urls[1]: must be a url, as nats://host:4222\n"+
+			"rabbitmq.host: is required when service is enabled\n"+
+			"rabbitmq.port: invalid port 70000, must be between 1 and 65535\n"+
+			"rabbitmq.heartbeat: must not be negative")
+	})
+
+	t.Run("should return named service errors", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Services: Services{
+				"payments": {Enabled: true},
+				"shipping": {Host: "https://shipping.domain", Retries: -1},
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "services.payments.host: is required when service is enabled\n"+
+			"services.shipping.retries: must not be negative")
+	})
+
+	t.Run("should return named database errors", func(t *testing.T) {
+		cfg := Config{
+			Server: Server{
+				Host: serverHost,
+				Port: serverPort,
+			},
+			Databases: Databases{
+				"analytics": {Driver: "oracle"},
+				"reporting": {Port: 70000},
+			},
+		}
+
+		err := cfg.Validate()
+		assert.EqualError(t, err, "databases.analytics.driver: unsupported driver \"oracle\"\n"+
+			"databases.reporting.driver: is required\n"+
+			"databases.reporting.port: invalid port 70000, must be between 1 and 65535")
+	})
+}
@@ -1,67 +1,197 @@
 package xml
 
 import (
+	"bytes"
 	"encoding/xml"
+	"errors"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/ribeirohugo/go_config/v2/pkg/config"
 )
 
+const tagName = "xml"
+
 // Load loads configurations from a given XML file path.
 func Load(filePath string) (config.XML, error) {
+	return load(filePath, decode)
+}
+
+// LoadStrict loads configurations from a given XML file path, returning an error for unknown elements.
+func LoadStrict(filePath string) (config.XML, error) {
+	return load(filePath, decodeStrict)
+}
+
+// LoadContent loads configurations from a given xml bytes content.
+func LoadContent(content []byte) (config.XML, error) {
+	return decode("", content)
+}
+
+// LoadContentStrict loads configurations from a given xml bytes content, returning an error for unknown elements.
+func LoadContentStrict(content []byte) (config.XML, error) {
+	return decodeStrict("", content)
+}
+
+func load(filePath string, decode func(string, []byte) (config.XML, error)) (config.XML, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return config.XML{}, err
+		return config.XML{}, &config.Error{Source: filePath, Err: err}
 	}
 
 	bytes, err := io.ReadAll(file)
 	if err != nil {
-		return config.XML{}, err
+		return config.XML{}, &config.Error{Source: filePath, Err: err}
 	}
 	_ = file.Close()
 
-	return LoadContent(bytes)
+	return decode(filePath, bytes)
 }
 
-// LoadContent loads configurations from a given xml bytes content.
-func LoadContent(content []byte) (config.XML, error) {
+func decode(source string, content []byte) (config.XML, error) {
 	cfg := config.XML{
-		Config: config.Config{
-			MySql: config.Database{
-				Port:           config.DefaultMySQLPort,
-				MigrationsPath: config.DefaultMigrationsMysql,
-			},
-			MongoDb: config.Database{
-				Port:           config.DefaultMongoPort,
-				MigrationsPath: config.DefaultMigrationsMongo,
-			},
-			Postgres: config.Database{
-				Port:           config.DefaultPostgresPort,
-				MigrationsPath: config.DefaultMigrationsPostgres,
-			},
-			Loki: config.ExternalService{
-				Host: config.DefaultLokiHost,
-			},
-			Tempo: config.ExternalService{
-				Host: config.DefaultTempoHost,
-			},
-			Redis: config.ExternalService{
-				Host: config.DefaultRedisHost,
-			},
-			Token: config.Token{
-				MaxAge: config.DefaultSessionMaxAge,
-			},
-			Jaeger: config.ExternalService{
-				Host: config.DefaultJaegerHost,
-			},
-		},
+		Config: config.Default(),
 	}
 
 	err := xml.Unmarshal(content, &cfg)
+	if err != nil {
+		return config.XML{}, decodeError(source, content, err)
+	}
+
+	cfg.Config, err = cfg.ApplyURLs(source)
 	if err != nil {
 		return config.XML{}, err
 	}
 
 	return cfg, nil
 }
+
+func decodeStrict(source string, content []byte) (config.XML, error) {
+	errs, err := findUnknownKeys(source, content)
+	if err != nil {
+		return config.XML{}, decodeError(source, content, err)
+	}
+	if len(errs) > 0 {
+		return config.XML{}, errors.Join(errs...)
+	}
+
+	return decode(source, content)
+}
+
+// decodeError wraps an xml error into a config.Error, with the error line when available.
+// Other errors, as invalid values, are located with the key path and line of the element failing to decode.
+func decodeError(source string, content []byte, err error) error {
+	cfgErr := &config.Error{Source: source, Err: err}
+
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		cfgErr.Line = syntaxErr.Line
+		return cfgErr
+	}
+
+	cfgErr.Key, cfgErr.Line = findInvalidElement(content)
+	return cfgErr
+}
+
+// findInvalidElement returns the key path and line of the first element whose value fails to decode,
+// decoding each element without nested elements alone, as encoding/xml value errors don't hold their location.
+func findInvalidElement(content []byte) (string, int) {
+	var (
+		path  []string
+		lines []int
+		text  []byte
+		leaf  bool
+	)
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		line, _ := decoder.InputPos()
+		token, err := decoder.Token()
+		if err != nil {
+			return "", 0
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			path = append(path, element.Name.Local)
+			lines = append(lines, line)
+			text = text[:0]
+			leaf = true
+		case xml.CharData:
+			text = append(text, element...)
+		case xml.EndElement:
+			if leaf && len(path) > 1 && decodeElement(path, text) != nil {
+				return strings.Join(path[1:], "."), lines[len(lines)-1]
+			}
+			path = path[:len(path)-1]
+			lines = lines[:len(lines)-1]
+			leaf = false
+		}
+	}
+}
+
+// decodeElement decodes a document holding only the element at path, with the given text.
+func decodeElement(path []string, text []byte) error {
+	var document bytes.Buffer
+	for _, name := range path {
+		document.WriteString("<" + name + ">")
+	}
+	_ = xml.EscapeText(&document, text)
+	for i := len(path) - 1; i >= 0; i-- {
+		document.WriteString("</" + path[i] + ">")
+	}
+
+	var cfg config.XML
+	return xml.Unmarshal(document.Bytes(), &cfg)
+}
+
+// findUnknownKeys reads elements nested in the root element and returns an error for each one not matching any Config field.
+func findUnknownKeys(source string, content []byte) ([]error, error) {
+	var (
+		errs  []error
+		path  []string
+		depth int
+	)
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		line, column := decoder.InputPos()
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return errs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 1 {
+				continue
+			}
+			keyPath := append(path[:len(path):len(path)], element.Name.Local)
+			if !config.KnownKey(tagName, keyPath...) {
+				errs = append(errs, &config.Error{
+					Source: source,
+					Key:    strings.Join(keyPath, "."),
+					Line:   line,
+					Column: column,
+					Err:    config.ErrUnknownKey,
+				})
+				err = decoder.Skip()
+				if err != nil {
+					return nil, err
+				}
+				depth--
+				continue
+			}
+			path = keyPath
+		case xml.EndElement:
+			depth--
+			if depth > 0 {
+				path = path[:len(path)-1]
+			}
+		}
+	}
+}
@@ -1,66 +1,160 @@
 package yaml
 
 import (
+	"bytes"
+	"errors"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/ribeirohugo/go_config/v2/pkg/config"
 )
 
+const tagName = "yaml"
+
+// linePattern matches the line prefix of yaml error messages, as in "line 3: ".
+var linePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): `)
+
+// unknownFieldPattern matches KnownFields error messages, as in "line 5: field pasword not found in type config.Database".
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (.+) not found in type `)
+
 // Load loads configurations from a given yaml file path.
 func Load(filePath string) (config.Config, error) {
+	return load(filePath, decode)
+}
+
+// LoadStrict loads configurations from a given yaml file path, returning an error for unknown keys.
+func LoadStrict(filePath string) (config.Config, error) {
+	return load(filePath, decodeStrict)
+}
+
+// LoadContent loads configurations from a given yaml bytes content.
+func LoadContent(content []byte) (config.Config, error) {
+	return decode("", content)
+}
+
+// LoadContentStrict loads configurations from a given yaml bytes content, returning an error for unknown keys.
+func LoadContentStrict(content []byte) (config.Config, error) {
+	return decodeStrict("", content)
+}
+
+func load(filePath string, decode func(string, []byte) (config.Config, error)) (config.Config, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return config.Config{}, err
+		return config.Config{}, &config.Error{Source: filePath, Err: err}
 	}
 
 	bytes, err := io.ReadAll(file)
 	if err != nil {
-		return config.Config{}, err
+		return config.Config{}, &config.Error{Source: filePath, Err: err}
 	}
 	_ = file.Close()
 
-	return LoadContent(bytes)
+	return decode(filePath, bytes)
 }
 
-// LoadContent loads configurations from a given yaml bytes content.
-func LoadContent(content []byte) (config.Config, error) {
-	cfg := config.Config{
-		MySql: config.Database{
-			Port:           config.DefaultMySQLPort,
-			MigrationsPath: config.DefaultMigrationsMysql,
-		},
-		MongoDb: config.Database{
-			Port:           config.DefaultMongoPort,
-			MigrationsPath: config.DefaultMigrationsMongo,
-		},
-		Postgres: config.Database{
-			Port:           config.DefaultPostgresPort,
-			MigrationsPath: config.DefaultMigrationsPostgres,
-		},
-		Token: config.Token{
-			MaxAge: config.DefaultSessionMaxAge,
-		},
-		Loki: config.ExternalService{
-			Host: config.DefaultLokiHost,
-		},
-		Tempo: config.ExternalService{
-			Host: config.DefaultTempoHost,
-		},
-		Jaeger: config.ExternalService{
-			Host: config.DefaultJaegerHost,
-		},
-		Redis: config.ExternalService{
-			Host: config.DefaultRedisHost,
-		},
-	}
+func decode(source string, content []byte) (config.Config, error) {
+	cfg := config.Default()
 
 	err := yaml.Unmarshal(content, &cfg)
 	if err != nil {
-		return config.Config{}, err
+		return config.Config{}, decodeError(source, content, err)
+	}
+
+	return cfg.ApplyURLs(source)
+}
+
+// decodeStrict decodes content with yaml.v3 KnownFields, which resolves merge keys and aliases before
+// reporting keys not matching any Config field.
+func decodeStrict(source string, content []byte) (config.Config, error) {
+	cfg := config.Default()
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err := decoder.Decode(&cfg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return config.Config{}, decodeError(source, content, err)
+	}
+
+	return cfg.ApplyURLs(source)
+}
+
+// decodeError wraps a yaml error into config.Error values, with the error line and key when available.
+// Type errors hold a message per invalid value, which are joined.
+func decodeError(source string, content []byte, err error) error {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return lineError(source, err, err.Error(), nil)
+	}
+
+	var document yaml.Node
+	_ = yaml.Unmarshal(content, &document)
+	keysByLine := make(map[int]string)
+	walkKeys(&document, nil, func(key *yaml.Node, path []string) bool {
+		keysByLine[key.Line] = strings.Join(path, ".")
+		return true
+	})
+
+	errs := make([]error, 0, len(typeErr.Errors))
+	for _, message := range typeErr.Errors {
+		if match := unknownFieldPattern.FindStringSubmatch(message); match != nil {
+			errs = append(errs, unknownKeyError(source, &document, match))
+			continue
+		}
+		errs = append(errs, lineError(source, typeErr, message, keysByLine))
+	}
+
+	return errors.Join(errs...)
+}
+
+// unknownKeyError builds a config.Error wrapping config.ErrUnknownKey from a KnownFields error message match,
+// with the path, line and column of the unknown key.
+func unknownKeyError(source string, document *yaml.Node, match []string) *config.Error {
+	cfgErr := &config.Error{Source: source, Key: match[2], Err: config.ErrUnknownKey}
+	cfgErr.Line, _ = strconv.Atoi(match[1])
+
+	walkKeys(document, nil, func(key *yaml.Node, path []string) bool {
+		if key.Line == cfgErr.Line && key.Value == match[2] {
+			cfgErr.Key = strings.Join(path, ".")
+			cfgErr.Column = key.Column
+		}
+		return true
+	})
+
+	return cfgErr
+}
+
+// lineError builds a config.Error wrapping err from one of its yaml error messages, moving the line prefix into Line.
+func lineError(source string, err error, message string, keysByLine map[int]string) *config.Error {
+	cfgErr := &config.Error{Source: source, Err: err, Message: message}
+
+	match := linePattern.FindStringSubmatch(message)
+	if match != nil {
+		cfgErr.Line, _ = strconv.Atoi(match[1])
+		cfgErr.Key = keysByLine[cfgErr.Line]
+		cfgErr.Message = "yaml: " + strings.TrimPrefix(message, match[0])
 	}
 
-	return cfg, nil
+	return cfgErr
+}
+
+// walkKeys calls walk for every mapping key, with its path. Values of keys for which walk returns false are skipped.
+func walkKeys(node *yaml.Node, path []string, walk func(key *yaml.Node, path []string) bool) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, item := range node.Content {
+			walkKeys(item, path, walk)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyPath := append(path[:len(path):len(path)], node.Content[i].Value)
+			if walk(node.Content[i], keyPath) {
+				walkKeys(node.Content[i+1], keyPath, walk)
+			}
+		}
+	}
 }
@@ -3,9 +3,11 @@ package yaml
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 
 	"github.com/ribeirohugo/go_config/v2/pkg/config"
 )
@@ -17,10 +19,37 @@ server:
   host: "localhost"
   port: 8080
   allowed_origins: ["http://localhost:8080"]
+  tls_cert_file: "/etc/ssl/server.crt"
+  tls_key_file: "/etc/ssl/server.key"
+  tls_min_version: "1.3"
+  read_timeout: "10s"
+  read_header_timeout: 2
+  write_timeout: "20s"
+  idle_timeout: "2m"
+  shutdown_timeout: "45s"
+  max_header_bytes: 4096
+  cors:
+    allowed_methods: ["GET", "POST"]
+    allowed_headers: ["Content-Type", "Authorization"]
+    exposed_headers: ["X-Request-Id"]
+    allow_credentials: true
+    max_age: "10m"
 
 token:
   secret: "token"
   max_age: 100
+  algorithm: "RS256"
+  issuer: "https://auth.domain"
+  audience:
+    - "orders"
+    - "payments"
+  refresh_max_age: "72h"
+  key_id: "2024-06"
+  private_key_file: "/etc/keys/token.pem"
+  public_key_file: "/etc/keys/token.pub"
+  verification_keys:
+    - key_id: "2024-01"
+      public_key_file: "/etc/keys/2024-01.pub"
 
 mongodb:
   database: "database"
@@ -28,6 +57,8 @@ mongodb:
   password: "password"
   port: 8080
   user: "username"
+  replica_set: "rs0"
+  options: "retryWrites=true"
 
 mysql:
   database: "database"
@@ -35,6 +66,7 @@ mysql:
   password: "password"
   port: 8080
   user: "username"
+  socket: "/var/run/mysqld/mysqld.sock"
 
 postgres:
   database: "database"
@@ -42,21 +74,74 @@ postgres:
   password: "password"
   port: 8080
   user: "username"
+  max_open_conns: 50
+  max_idle_conns: 10
+  conn_max_lifetime: "1h"
+  conn_max_idle_time: "10m"
+  replicas:
+    - host: "replica1.domain"
+    - host: "replica2.domain"
+      port: 5433
+      user: "reader"
+      password: "reader.password"
+
+sqlite:
+  path: "/var/lib/app/app.db"
+  pragmas:
+    - "journal_mode=WAL"
+    - "busy_timeout=5000"
+
+sqlserver:
+  host: "sqlserver.domain"
+  user: "username"
+  password: "password"
+  database: "database"
+
+clickhouse:
+  host: "clickhouse.domain"
+  database: "analytics"
+
+databases:
+  analytics:
+    driver: "postgres"
+    host: "analytics.domain"
+    port: 5433
+    user: "username"
+    password: "password"
+    database: "analytics"
 
 audit:
   enabled: true
   host: "audit.domain"
   token: "audit.token"
+  timeout: "5s"
+  retries: 3
+  headers:
+    X-Source: "orders"
+  tls_ca_file: "ca.crt"
 
 loki:
   enabled: true
   host: "loki.domain"
   token: "loki.token"
+  tenant_id: "shop"
+  labels:
+    team: "payments"
+  batch_size: 524288
+  batch_wait: "2s"
 
 prometheus:
   enabled: true
   host: "prometheus.domain"
+  port: 9100
+  path: "/internal/metrics"
+  namespace: "shop"
+  subsystem: "orders"
+  basic_auth:
+    username: "prometheus.user"
+    password: "prometheus.password"
   token: "prometheus.token"
+  pushgateway_url: "http://pushgateway.domain:9091"
 
 tempo:
   enabled: true
@@ -72,6 +157,71 @@ redis:
   enabled: true
   host: "redis.domain"
   token: "redis.token"
+  username: "redis.user"
+  password: "redis.password"
+  db: 2
+  tls: true
+  pool_size: 20
+  dial_timeout: "10s"
+  read_timeout: "2s"
+  write_timeout: "4s"
+  master_name: "mymaster"
+  sentinel_addresses: ["sentinel1:26379", "sentinel2:26379"]
+  cluster_addresses: ["node1:6379", "node2:6379"]
+
+otel:
+  enabled: true
+  service_name: "service.otel"
+  resource_attributes:
+    service.namespace: "shop"
+  sample_ratio: 0.5
+  endpoint: "http://otel.domain:4317"
+  protocol: "grpc"
+  headers:
+    authorization: "Bearer otel.token"
+  traces:
+    endpoint: "http://tempo.domain:4318/v1/traces"
+    protocol: "http/protobuf"
+  metrics:
+    exporter: "none"
+
+log:
+  level: "debug"
+  format: "json"
+  output: "stderr"
+  source: true
+  levels:
+    main: "warn"
+
+services:
+  payments:
+    enabled: true
+    host: "https://payments.domain"
+    token: "payments.token"
+    retries: 2
+
+kafka:
+  enabled: true
+  brokers: ["kafka1.domain:9092", "kafka2.domain:9092"]
+  client_id: "service"
+  consumer_group: "service.group"
+  sasl:
+    mechanism: "SCRAM-SHA-512"
+    username: "kafka.user"
+    password: "kafka.password"
+  tls: true
+
+nats:
+  enabled: true
+  urls: ["nats://nats1.domain:4222", "nats://nats2.domain:4222"]
+  credentials_file: "/etc/nats/user.creds"
+
+rabbitmq:
+  enabled: true
+  host: "rabbitmq.domain"
+  user: "rabbitmq.user"
+  password: "rabbitmq.password"
+  vhost: "events"
 
 settings:
   setting1: "value1"
@@ -85,27 +235,39 @@ server:
 	port: "9399"
 `
 
+const configContentUnknownKeys = `environment: "dev"
+server:
+  host: "localhost"
+postgres:
+  pasword: "password"
+tracer:
+  enabled: true
+  host: "https://tracer.domain"
+settings:
+  setting1: "value1"
+`
+
 func TestLoadYaml(t *testing.T) {
 	const (
-		environment     = "dev"
-		service         = "safesystem"
-		serverHost      = "localhost"
-		serverPort      = 8080
-		database        = "database"
-		password        = "password"
-		username        = "username"
-		auditHost       = "audit.domain"
-		auditToken      = "audit.token"
-		lokiHost        = "loki.domain"
-		lokiToken       = "loki.token"
-		prometheusHost  = "prometheus.domain"
-		prometheusToken = "prometheus.token"
-		tempoHost       = "tempo.domain"
-		tempoToken      = "tempo.token"
-		jaegerHost      = "jaeger.domain"
-		jaegerToken     = "jaeger.token"
-		redisHost       = "redis.domain"
-		redisToken      = "redis.token"
+		environment        = "dev"
+		service            = "safesystem"
+		serverHost         = "localhost"
+		serverPort         = 8080
+		database           = "database"
+		password           = "password"
+		username           = "username"
+		auditHost          = "audit.domain"
+		auditToken         = "audit.token"
+		lokiHost           = "loki.domain"
+		lokiToken          = "loki.token"
+		prometheusHost     = "prometheus.domain"
+		prometheusPassword = "prometheus.password"
+		tempoHost          = "tempo.domain"
+		tempoToken         = "tempo.token"
+		jaegerHost         = "jaeger.domain"
+		jaegerToken        = "jaeger.token"
+		redisHost          = "redis.domain"
+		redisToken         = "redis.token"
 	)
 	settings := map[string]string{
 		"setting1": "value1",
@@ -117,10 +279,36 @@ func TestLoadYaml(t *testing.T) {
 			Host:           serverHost,
 			Port:           serverPort,
 			AllowedOrigins: []string{"http://localhost:8080"},
+			CORS: config.CORS{
+				AllowedMethods:   []string{"GET", "POST"},
+				AllowedHeaders:   []string{"Content-Type", "Authorization"},
+				ExposedHeaders:   []string{"X-Request-Id"},
+				AllowCredentials: true,
+				MaxAge:           config.Duration(10 * time.Minute),
+			},
+			TLSCertFile:       "/etc/ssl/server.crt",
+			TLSKeyFile:        "/etc/ssl/server.key",
+			TLSMinVersion:     "1.3",
+			ReadTimeout:       config.Duration(10 * time.Second),
+			ReadHeaderTimeout: config.Duration(2 * time.Second),
+			WriteTimeout:      config.Duration(20 * time.Second),
+			IdleTimeout:       config.Duration(2 * time.Minute),
+			ShutdownTimeout:   config.Duration(45 * time.Second),
+			MaxHeaderBytes:    4096,
 		},
 		Token: config.Token{
-			MaxAge: 100,
-			Secret: "token",
+			MaxAge:         config.Duration(100 * time.Second),
+			Secret:         "token",
+			Algorithm:      "RS256",
+			Issuer:         "https://auth.domain",
+			Audience:       []string{"orders", "payments"},
+			RefreshMaxAge:  config.Duration(72 * time.Hour),
+			KeyID:          "2024-06",
+			PrivateKeyFile: "/etc/keys/token.pem",
+			PublicKeyFile:  "/etc/keys/token.pub",
+			VerificationKeys: []config.TokenKey{
+				{KeyID: "2024-01", PublicKeyFile: "/etc/keys/2024-01.pub"},
+			},
 		},
 		MongoDb: config.Database{
 			Host:           serverHost,
@@ -129,52 +317,198 @@ func TestLoadYaml(t *testing.T) {
 			Password:       password,
 			Db:             database,
 			MigrationsPath: config.DefaultMigrationsMongo,
+			ReplicaSet:     "rs0",
+			Options:        "retryWrites=true",
 		},
 		MySql: config.Database{
-			Host:           serverHost,
-			Port:           serverPort,
-			User:           username,
-			Password:       password,
-			Db:             database,
-			MigrationsPath: config.DefaultMigrationsMysql,
+			Host:            serverHost,
+			Port:            serverPort,
+			User:            username,
+			Password:        password,
+			Db:              database,
+			Socket:          "/var/run/mysqld/mysqld.sock",
+			MigrationsPath:  config.DefaultMigrationsMysql,
+			MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: config.Duration(30 * time.Minute),
+			ConnMaxIdleTime: config.Duration(5 * time.Minute),
 		},
 		Postgres: config.Database{
-			Host:           serverHost,
-			Port:           serverPort,
-			User:           username,
-			Password:       password,
-			Db:             database,
-			MigrationsPath: config.DefaultMigrationsPostgres,
+			Host:            serverHost,
+			Port:            serverPort,
+			User:            username,
+			Password:        password,
+			Db:              database,
+			MigrationsPath:  config.DefaultMigrationsPostgres,
+			MaxOpenConns:    50,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: config.Duration(time.Hour),
+			ConnMaxIdleTime: config.Duration(10 * time.Minute),
+			Replicas: []config.Replica{
+				{Host: "replica1.domain"},
+				{Host: "replica2.domain", Port: 5433, User: "reader", Password: "reader.password"},
+			},
+		},
+		SQLite: config.Database{
+			Path:           "/var/lib/app/app.db",
+			Pragmas:        []string{"journal_mode=WAL", "busy_timeout=5000"},
+			MigrationsPath: config.DefaultMigrationsSQLite,
+		},
+		SQLServer: config.Database{
+			Host:            "sqlserver.domain",
+			Port:            config.DefaultSQLServerPort,
+			User:            username,
+			Password:        password,
+			Db:              database,
+			MigrationsPath:  config.DefaultMigrationsSQLServer,
+			MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: config.Duration(30 * time.Minute),
+			ConnMaxIdleTime: config.Duration(5 * time.Minute),
+		},
+		ClickHouse: config.Database{
+			Host:            "clickhouse.domain",
+			Port:            config.DefaultClickHousePort,
+			Db:              "analytics",
+			MigrationsPath:  config.DefaultMigrationsClickHouse,
+			MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: config.Duration(30 * time.Minute),
+			ConnMaxIdleTime: config.Duration(5 * time.Minute),
+		},
+		Databases: config.Databases{
+			"analytics": {
+				Driver:          config.DriverPostgres,
+				Host:            "analytics.domain",
+				Port:            5433,
+				User:            username,
+				Password:        password,
+				Db:              "analytics",
+				MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+				MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+				ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+				ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
+			},
 		},
 		Audit: config.ExternalService{
-			Enabled: true,
-			Host:    auditHost,
-			Token:   auditToken,
+			Enabled:   true,
+			Host:      auditHost,
+			Token:     auditToken,
+			Timeout:   config.Duration(5 * time.Second),
+			Retries:   3,
+			Headers:   config.Attributes{"X-Source": "orders"},
+			TLSCAFile: "ca.crt",
 		},
-		Loki: config.ExternalService{
-			Enabled: true,
-			Host:    lokiHost,
-			Token:   lokiToken,
+		Loki: config.Loki{
+			Enabled:   true,
+			Host:      lokiHost,
+			Token:     lokiToken,
+			TenantID:  "shop",
+			Labels:    config.Attributes{"team": "payments"},
+			BatchSize: 524288,
+			BatchWait: config.Duration(2 * time.Second),
+			Timeout:   config.Duration(10 * time.Second),
 		},
-		Prometheus: config.ExternalService{
-			Enabled: true,
-			Host:    prometheusHost,
-			Token:   prometheusToken,
+		Prometheus: config.Prometheus{
+			Enabled:        true,
+			Host:           prometheusHost,
+			Port:           9100,
+			Path:           "/internal/metrics",
+			Namespace:      "shop",
+			Subsystem:      "orders",
+			BasicAuth:      config.BasicAuth{Username: "prometheus.user", Password: prometheusPassword},
+			Token:          "prometheus.token",
+			PushgatewayURL: "http://pushgateway.domain:9091",
 		},
 		Tempo: config.ExternalService{
 			Enabled: true,
 			Host:    tempoHost,
 			Token:   tempoToken,
+			Timeout: config.Duration(10 * time.Second),
 		},
 		Jaeger: config.ExternalService{
 			Enabled: true,
 			Host:    jaegerHost,
 			Token:   jaegerToken,
+			Timeout: config.Duration(10 * time.Second),
 		},
-		Redis: config.ExternalService{
-			Enabled: true,
-			Host:    redisHost,
-			Token:   redisToken,
+		Redis: config.Redis{
+			Enabled:           true,
+			Host:              redisHost,
+			Token:             redisToken,
+			Username:          "redis.user",
+			Password:          "redis.password",
+			DB:                2,
+			TLS:               true,
+			PoolSize:          20,
+			DialTimeout:       config.Duration(10 * time.Second),
+			ReadTimeout:       config.Duration(2 * time.Second),
+			WriteTimeout:      config.Duration(4 * time.Second),
+			MasterName:        "mymaster",
+			SentinelAddresses: []string{"sentinel1:26379", "sentinel2:26379"},
+			ClusterAddresses:  []string{"node1:6379", "node2:6379"},
+		},
+		OTel: config.OTel{
+			Enabled:            true,
+			ServiceName:        "service.otel",
+			ResourceAttributes: config.Attributes{"service.namespace": "shop"},
+			SampleRatio:        0.5,
+			MetricsInterval:    config.Duration(time.Minute),
+			Endpoint:           "http://otel.domain:4317",
+			Protocol:           config.OTelProtocolGRPC,
+			Headers:            config.Attributes{"authorization": "Bearer otel.token"},
+			Timeout:            config.Duration(10 * time.Second),
+			Traces: config.OTelExporter{
+				Exporter: config.OTelExporterOTLP,
+				Endpoint: "http://tempo.domain:4318/v1/traces",
+				Protocol: config.OTelProtocolHTTPProtobuf,
+			},
+			Metrics: config.OTelExporter{Exporter: config.OTelExporterNone},
+			Logs:    config.OTelExporter{Exporter: config.OTelExporterOTLP},
+		},
+		Log: config.Logging{
+			Level:  "debug",
+			Format: config.LogFormatJSON,
+			Output: config.LogOutputStderr,
+			Source: true,
+			Levels: config.Attributes{"main": "warn"},
+		},
+		Services: config.Services{
+			"payments": {
+				Enabled: true,
+				Host:    "https://payments.domain",
+				Token:   "payments.token",
+				Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
+				Retries: 2,
+			},
+		},
+		Kafka: config.Kafka{
+			Enabled:       true,
+			Brokers:       []string{"kafka1.domain:9092", "kafka2.domain:9092"},
+			ClientID:      "service",
+			ConsumerGroup: "service.group",
+			DialTimeout:   config.Duration(10 * time.Second),
+			SASL: config.SASL{
+				Mechanism: config.SASLScramSHA512,
+				Username:  "kafka.user",
+				Password:  "kafka.password",
+			},
+			TLS: true,
+		},
+		NATS: config.NATS{
+			Enabled:         true,
+			URLs:            []string{"nats://nats1.domain:4222", "nats://nats2.domain:4222"},
+			CredentialsFile: "/etc/nats/user.creds",
+			ConnectTimeout:  config.Duration(2 * time.Second),
+		},
+		RabbitMQ: config.RabbitMQ{
+			Enabled:   true,
+			Host:      "rabbitmq.domain",
+			Port:      config.DefaultRabbitMQPort,
+			User:      "rabbitmq.user",
+			Password:  "rabbitmq.password",
+			VHost:     "events",
+			Heartbeat: config.Duration(10 * time.Second),
 		},
 		Environment: environment,
 		Service:     service,
@@ -194,32 +528,115 @@ func TestLoadYaml(t *testing.T) {
 
 		t.Run("without optional fields", func(t *testing.T) {
 			expectedConfig := config.Config{
+				Server: config.Server{
+					ReadTimeout:       config.Duration(config.DefaultServerReadTimeout * time.Second),
+					ReadHeaderTimeout: config.Duration(config.DefaultServerReadHeaderTimeout * time.Second),
+					WriteTimeout:      config.Duration(config.DefaultServerWriteTimeout * time.Second),
+					IdleTimeout:       config.Duration(config.DefaultServerIdleTimeout * time.Second),
+					ShutdownTimeout:   config.Duration(config.DefaultServerShutdownTimeout * time.Second),
+					MaxHeaderBytes:    config.DefaultServerMaxHeaderBytes,
+					TLSMinVersion:     config.DefaultServerTLSMinVersion,
+				},
 				MySql: config.Database{
-					Port:           config.DefaultMySQLPort,
-					MigrationsPath: config.DefaultMigrationsMysql,
+					Port:            config.DefaultMySQLPort,
+					MigrationsPath:  config.DefaultMigrationsMysql,
+					MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+					MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+					ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+					ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
 				},
 				MongoDb: config.Database{
 					Port:           config.DefaultMongoPort,
 					MigrationsPath: config.DefaultMigrationsMongo,
 				},
 				Postgres: config.Database{
-					Port:           config.DefaultPostgresPort,
-					MigrationsPath: config.DefaultMigrationsPostgres,
+					Port:            config.DefaultPostgresPort,
+					MigrationsPath:  config.DefaultMigrationsPostgres,
+					MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+					MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+					ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+					ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
+				},
+				SQLite: config.Database{
+					MigrationsPath: config.DefaultMigrationsSQLite,
+				},
+				SQLServer: config.Database{
+					Port:            config.DefaultSQLServerPort,
+					MigrationsPath:  config.DefaultMigrationsSQLServer,
+					MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+					MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+					ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+					ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
 				},
-				Loki: config.ExternalService{
-					Host: config.DefaultLokiHost,
+				ClickHouse: config.Database{
+					Port:            config.DefaultClickHousePort,
+					MigrationsPath:  config.DefaultMigrationsClickHouse,
+					MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+					MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+					ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+					ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
+				},
+				Token: config.Token{
+					MaxAge:        config.Duration(config.DefaultSessionMaxAge * time.Second),
+					Algorithm:     config.DefaultTokenAlgorithm,
+					RefreshMaxAge: config.Duration(config.DefaultRefreshMaxAge * time.Second),
+				},
+				Audit: config.ExternalService{
+					Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
+				},
+				Loki: config.Loki{
+					Host:      config.DefaultLokiHost,
+					BatchSize: config.DefaultLokiBatchSize,
+					BatchWait: config.Duration(config.DefaultLokiBatchWait * time.Second),
+					Timeout:   config.Duration(config.DefaultLokiTimeout * time.Second),
 				},
 				Tempo: config.ExternalService{
-					Host: config.DefaultTempoHost,
+					Host:    config.DefaultTempoHost,
+					Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
 				},
 				Jaeger: config.ExternalService{
-					Host: config.DefaultJaegerHost,
+					Host:    config.DefaultJaegerHost,
+					Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
 				},
-				Redis: config.ExternalService{
-					Host: config.DefaultRedisHost,
+				Prometheus: config.Prometheus{
+					Port: config.DefaultPrometheusPort,
+					Path: config.DefaultPrometheusPath,
 				},
-				Token: config.Token{
-					MaxAge: config.DefaultSessionMaxAge,
+				Redis: config.Redis{
+					Host:         config.DefaultRedisHost,
+					DialTimeout:  config.Duration(config.DefaultRedisDialTimeout * time.Second),
+					ReadTimeout:  config.Duration(config.DefaultRedisReadTimeout * time.Second),
+					WriteTimeout: config.Duration(config.DefaultRedisWriteTimeout * time.Second),
+				},
+				OTel: config.OTel{
+					SampleRatio:     config.DefaultOTelSampleRatio,
+					MetricsInterval: config.Duration(config.DefaultOTelMetricsInterval * time.Second),
+					Endpoint:        config.DefaultOTelEndpoint,
+					Protocol:        config.DefaultOTelProtocol,
+					Timeout:         config.Duration(config.DefaultOTelTimeout * time.Second),
+					Traces:          config.OTelExporter{Exporter: config.DefaultOTelExporter},
+					Metrics:         config.OTelExporter{Exporter: config.DefaultOTelExporter},
+					Logs:            config.OTelExporter{Exporter: config.DefaultOTelExporter},
+				},
+				Log: config.Logging{
+					Level:  config.DefaultLogLevel,
+					Format: config.DefaultLogFormat,
+					Output: config.DefaultLogOutput,
+				},
+				Kafka: config.Kafka{
+					DialTimeout: config.Duration(config.DefaultKafkaDialTimeout * time.Second),
+					SASL: config.SASL{
+						Mechanism: config.DefaultKafkaSASLMechanism,
+					},
+				},
+				NATS: config.NATS{
+					ConnectTimeout: config.Duration(config.DefaultNATSConnectTimeout * time.Second),
+				},
+				RabbitMQ: config.RabbitMQ{
+					Host:      config.DefaultRabbitMQHost,
+					Port:      config.DefaultRabbitMQPort,
+					VHost:     config.DefaultRabbitMQVHost,
+					Heartbeat: config.Duration(config.DefaultRabbitMQHeartbeat * time.Second),
 				},
 			}
 
@@ -249,30 +666,44 @@ func TestLoadYaml(t *testing.T) {
 
 			closeFile(t, tempFile)
 		})
+
+		t.Run("invalid file content with error location", func(t *testing.T) {
+			tempFile := createTempFile(t, configContentInvalid)
+
+			_, err := Load(tempFile.Name())
+
+			var cfgErr *config.Error
+			require.ErrorAs(t, err, &cfgErr)
+			assert.Equal(t, tempFile.Name(), cfgErr.Source)
+			assert.Equal(t, 3, cfgErr.Line)
+			assert.Equal(t, "", cfgErr.Key)
+
+			closeFile(t, tempFile)
+		})
 	})
 }
 
 func TestLoadContent(t *testing.T) {
 	const (
-		environment     = "dev"
-		service         = "safesystem"
-		serverHost      = "localhost"
-		serverPort      = 8080
-		database        = "database"
-		password        = "password"
-		username        = "username"
-		auditHost       = "audit.domain"
-		auditToken      = "audit.token"
-		lokiHost        = "loki.domain"
-		lokiToken       = "loki.token"
-		prometheusHost  = "prometheus.domain"
-		prometheusToken = "prometheus.token"
-		tempoHost       = "tempo.domain"
-		tempoToken      = "tempo.token"
-		jaegerHost      = "jaeger.domain"
-		jaegerToken     = "jaeger.token"
-		redisHost       = "redis.domain"
-		redisToken      = "redis.token"
+		environment        = "dev"
+		service            = "safesystem"
+		serverHost         = "localhost"
+		serverPort         = 8080
+		database           = "database"
+		password           = "password"
+		username           = "username"
+		auditHost          = "audit.domain"
+		auditToken         = "audit.token"
+		lokiHost           = "loki.domain"
+		lokiToken          = "loki.token"
+		prometheusHost     = "prometheus.domain"
+		prometheusPassword = "prometheus.password"
+		tempoHost          = "tempo.domain"
+		tempoToken         = "tempo.token"
+		jaegerHost         = "jaeger.domain"
+		jaegerToken        = "jaeger.token"
+		redisHost          = "redis.domain"
+		redisToken         = "redis.token"
 	)
 	settings := map[string]string{
 		"setting1": "value1",
@@ -284,10 +715,36 @@ func TestLoadContent(t *testing.T) {
 			Host:           serverHost,
 			Port:           serverPort,
 			AllowedOrigins: []string{"http://localhost:8080"},
+			CORS: config.CORS{
+				AllowedMethods:   []string{"GET", "POST"},
+				AllowedHeaders:   []string{"Content-Type", "Authorization"},
+				ExposedHeaders:   []string{"X-Request-Id"},
+				AllowCredentials: true,
+				MaxAge:           config.Duration(10 * time.Minute),
+			},
+			TLSCertFile:       "/etc/ssl/server.crt",
+			TLSKeyFile:        "/etc/ssl/server.key",
+			TLSMinVersion:     "1.3",
+			ReadTimeout:       config.Duration(10 * time.Second),
+			ReadHeaderTimeout: config.Duration(2 * time.Second),
+			WriteTimeout:      config.Duration(20 * time.Second),
+			IdleTimeout:       config.Duration(2 * time.Minute),
+			ShutdownTimeout:   config.Duration(45 * time.Second),
+			MaxHeaderBytes:    4096,
 		},
 		Token: config.Token{
-			MaxAge: 100,
-			Secret: "token",
+			MaxAge:         config.Duration(100 * time.Second),
+			Secret:         "token",
+			Algorithm:      "RS256",
+			Issuer:         "https://auth.domain",
+			Audience:       []string{"orders", "payments"},
+			RefreshMaxAge:  config.Duration(72 * time.Hour),
+			KeyID:          "2024-06",
+			PrivateKeyFile: "/etc/keys/token.pem",
+			PublicKeyFile:  "/etc/keys/token.pub",
+			VerificationKeys: []config.TokenKey{
+				{KeyID: "2024-01", PublicKeyFile: "/etc/keys/2024-01.pub"},
+			},
 		},
 		MongoDb: config.Database{
 			Host:           serverHost,
@@ -296,52 +753,198 @@ func TestLoadContent(t *testing.T) {
 			Password:       password,
 			Db:             database,
 			MigrationsPath: config.DefaultMigrationsMongo,
+			ReplicaSet:     "rs0",
+			Options:        "retryWrites=true",
 		},
 		MySql: config.Database{
-			Host:           serverHost,
-			Port:           serverPort,
-			User:           username,
-			Password:       password,
-			Db:             database,
-			MigrationsPath: config.DefaultMigrationsMysql,
+			Host:            serverHost,
+			Port:            serverPort,
+			User:            username,
+			Password:        password,
+			Db:              database,
+			Socket:          "/var/run/mysqld/mysqld.sock",
+			MigrationsPath:  config.DefaultMigrationsMysql,
+			MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: config.Duration(30 * time.Minute),
+			ConnMaxIdleTime: config.Duration(5 * time.Minute),
 		},
 		Postgres: config.Database{
-			Host:           serverHost,
-			Port:           serverPort,
-			User:           username,
-			Password:       password,
-			Db:             database,
-			MigrationsPath: config.DefaultMigrationsPostgres,
+			Host:            serverHost,
+			Port:            serverPort,
+			User:            username,
+			Password:        password,
+			Db:              database,
+			MigrationsPath:  config.DefaultMigrationsPostgres,
+			MaxOpenConns:    50,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: config.Duration(time.Hour),
+			ConnMaxIdleTime: config.Duration(10 * time.Minute),
+			Replicas: []config.Replica{
+				{Host: "replica1.domain"},
+				{Host: "replica2.domain", Port: 5433, User: "reader", Password: "reader.password"},
+			},
+		},
+		SQLite: config.Database{
+			Path:           "/var/lib/app/app.db",
+			Pragmas:        []string{"journal_mode=WAL", "busy_timeout=5000"},
+			MigrationsPath: config.DefaultMigrationsSQLite,
+		},
+		SQLServer: config.Database{
+			Host:            "sqlserver.domain",
+			Port:            config.DefaultSQLServerPort,
+			User:            username,
+			Password:        password,
+			Db:              database,
+			MigrationsPath:  config.DefaultMigrationsSQLServer,
+			MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: config.Duration(30 * time.Minute),
+			ConnMaxIdleTime: config.Duration(5 * time.Minute),
+		},
+		ClickHouse: config.Database{
+			Host:            "clickhouse.domain",
+			Port:            config.DefaultClickHousePort,
+			Db:              "analytics",
+			MigrationsPath:  config.DefaultMigrationsClickHouse,
+			MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+			MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+			ConnMaxLifetime: config.Duration(30 * time.Minute),
+			ConnMaxIdleTime: config.Duration(5 * time.Minute),
+		},
+		Databases: config.Databases{
+			"analytics": {
+				Driver:          config.DriverPostgres,
+				Host:            "analytics.domain",
+				Port:            5433,
+				User:            username,
+				Password:        password,
+				Db:              "analytics",
+				MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+				MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+				ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+				ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
+			},
 		},
 		Audit: config.ExternalService{
-			Enabled: true,
-			Host:    auditHost,
-			Token:   auditToken,
+			Enabled:   true,
+			Host:      auditHost,
+			Token:     auditToken,
+			Timeout:   config.Duration(5 * time.Second),
+			Retries:   3,
+			Headers:   config.Attributes{"X-Source": "orders"},
+			TLSCAFile: "ca.crt",
 		},
-		Loki: config.ExternalService{
-			Enabled: true,
-			Host:    lokiHost,
-			Token:   lokiToken,
+		Loki: config.Loki{
+			Enabled:   true,
+			Host:      lokiHost,
+			Token:     lokiToken,
+			TenantID:  "shop",
+			Labels:    config.Attributes{"team": "payments"},
+			BatchSize: 524288,
+			BatchWait: config.Duration(2 * time.Second),
+			Timeout:   config.Duration(10 * time.Second),
 		},
-		Prometheus: config.ExternalService{
-			Enabled: true,
-			Host:    prometheusHost,
-			Token:   prometheusToken,
+		Prometheus: config.Prometheus{
+			Enabled:        true,
+			Host:           prometheusHost,
+			Port:           9100,
+			Path:           "/internal/metrics",
+			Namespace:      "shop",
+			Subsystem:      "orders",
+			BasicAuth:      config.BasicAuth{Username: "prometheus.user", Password: prometheusPassword},
+			Token:          "prometheus.token",
+			PushgatewayURL: "http://pushgateway.domain:9091",
 		},
 		Tempo: config.ExternalService{
 			Enabled: true,
 			Host:    tempoHost,
 			Token:   tempoToken,
+			Timeout: config.Duration(10 * time.Second),
 		},
 		Jaeger: config.ExternalService{
 			Enabled: true,
 			Host:    jaegerHost,
 			Token:   jaegerToken,
+			Timeout: config.Duration(10 * time.Second),
 		},
-		Redis: config.ExternalService{
-			Enabled: true,
-			Host:    redisHost,
-			Token:   redisToken,
+		Redis: config.Redis{
+			Enabled:           true,
+			Host:              redisHost,
+			Token:             redisToken,
+			Username:          "redis.user",
+			Password:          "redis.password",
+			DB:                2,
+			TLS:               true,
+			PoolSize:          20,
+			DialTimeout:       config.Duration(10 * time.Second),
+			ReadTimeout:       config.Duration(2 * time.Second),
+			WriteTimeout:      config.Duration(4 * time.Second),
+			MasterName:        "mymaster",
+			SentinelAddresses: []string{"sentinel1:26379", "sentinel2:26379"},
+			ClusterAddresses:  []string{"node1:6379", "node2:6379"},
+		},
+		OTel: config.OTel{
+			Enabled:            true,
+			ServiceName:        "service.otel",
+			ResourceAttributes: config.Attributes{"service.namespace": "shop"},
+			SampleRatio:        0.5,
+			MetricsInterval:    config.Duration(time.Minute),
+			Endpoint:           "http://otel.domain:4317",
+			Protocol:           config.OTelProtocolGRPC,
+			Headers:            config.Attributes{"authorization": "Bearer otel.token"},
+			Timeout:            config.Duration(10 * time.Second),
+			Traces: config.OTelExporter{
+				Exporter: config.OTelExporterOTLP,
+				Endpoint: "http://tempo.domain:4318/v1/traces",
+				Protocol: config.OTelProtocolHTTPProtobuf,
+			},
+			Metrics: config.OTelExporter{Exporter: config.OTelExporterNone},
+			Logs:    config.OTelExporter{Exporter: config.OTelExporterOTLP},
+		},
+		Log: config.Logging{
+			Level:  "debug",
+			Format: config.LogFormatJSON,
+			Output: config.LogOutputStderr,
+			Source: true,
+			Levels: config.Attributes{"main": "warn"},
+		},
+		Services: config.Services{
+			"payments": {
+				Enabled: true,
+				Host:    "https://payments.domain",
+				Token:   "payments.token",
+				Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
+				Retries: 2,
+			},
+		},
+		Kafka: config.Kafka{
+			Enabled:       true,
+			Brokers:       []string{"kafka1.domain:9092", "kafka2.domain:9092"},
+			ClientID:      "service",
+			ConsumerGroup: "service.group",
+			DialTimeout:   config.Duration(10 * time.Second),
+			SASL: config.SASL{
+				Mechanism: config.SASLScramSHA512,
+				Username:  "kafka.user",
+				Password:  "kafka.password",
+			},
+			TLS: true,
+		},
+		NATS: config.NATS{
+			Enabled:         true,
+			URLs:            []string{"nats://nats1.domain:4222", "nats://nats2.domain:4222"},
+			CredentialsFile: "/etc/nats/user.creds",
+			ConnectTimeout:  config.Duration(2 * time.Second),
+		},
+		RabbitMQ: config.RabbitMQ{
+			Enabled:   true,
+			Host:      "rabbitmq.domain",
+			Port:      config.DefaultRabbitMQPort,
+			User:      "rabbitmq.user",
+			Password:  "rabbitmq.password",
+			VHost:     "events",
+			Heartbeat: config.Duration(10 * time.Second),
 		},
 		Environment: environment,
 		Service:     service,
@@ -357,32 +960,115 @@ func TestLoadContent(t *testing.T) {
 
 		t.Run("without optional fields", func(t *testing.T) {
 			expectedConfig := config.Config{
+				Server: config.Server{
+					ReadTimeout:       config.Duration(config.DefaultServerReadTimeout * time.Second),
+					ReadHeaderTimeout: config.Duration(config.DefaultServerReadHeaderTimeout * time.Second),
+					WriteTimeout:      config.Duration(config.DefaultServerWriteTimeout * time.Second),
+					IdleTimeout:       config.Duration(config.DefaultServerIdleTimeout * time.Second),
+					ShutdownTimeout:   config.Duration(config.DefaultServerShutdownTimeout * time.Second),
+					MaxHeaderBytes:    config.DefaultServerMaxHeaderBytes,
+					TLSMinVersion:     config.DefaultServerTLSMinVersion,
+				},
 				MySql: config.Database{
-					Port:           config.DefaultMySQLPort,
-					MigrationsPath: config.DefaultMigrationsMysql,
+					Port:            config.DefaultMySQLPort,
+					MigrationsPath:  config.DefaultMigrationsMysql,
+					MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+					MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+					ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+					ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
 				},
 				MongoDb: config.Database{
 					Port:           config.DefaultMongoPort,
 					MigrationsPath: config.DefaultMigrationsMongo,
 				},
 				Postgres: config.Database{
-					Port:           config.DefaultPostgresPort,
-					MigrationsPath: config.DefaultMigrationsPostgres,
+					Port:            config.DefaultPostgresPort,
+					MigrationsPath:  config.DefaultMigrationsPostgres,
+					MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+					MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+					ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+					ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
+				},
+				SQLite: config.Database{
+					MigrationsPath: config.DefaultMigrationsSQLite,
+				},
+				SQLServer: config.Database{
+					Port:            config.DefaultSQLServerPort,
+					MigrationsPath:  config.DefaultMigrationsSQLServer,
+					MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+					MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+					ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+					ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
 				},
-				Loki: config.ExternalService{
-					Host: config.DefaultLokiHost,
+				ClickHouse: config.Database{
+					Port:            config.DefaultClickHousePort,
+					MigrationsPath:  config.DefaultMigrationsClickHouse,
+					MaxOpenConns:    config.DefaultDatabaseMaxOpenConns,
+					MaxIdleConns:    config.DefaultDatabaseMaxIdleConns,
+					ConnMaxLifetime: config.Duration(config.DefaultDatabaseConnMaxLifetime * time.Second),
+					ConnMaxIdleTime: config.Duration(config.DefaultDatabaseConnMaxIdleTime * time.Second),
+				},
+				Token: config.Token{
+					MaxAge:        config.Duration(config.DefaultSessionMaxAge * time.Second),
+					Algorithm:     config.DefaultTokenAlgorithm,
+					RefreshMaxAge: config.Duration(config.DefaultRefreshMaxAge * time.Second),
+				},
+				Audit: config.ExternalService{
+					Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
+				},
+				Loki: config.Loki{
+					Host:      config.DefaultLokiHost,
+					BatchSize: config.DefaultLokiBatchSize,
+					BatchWait: config.Duration(config.DefaultLokiBatchWait * time.Second),
+					Timeout:   config.Duration(config.DefaultLokiTimeout * time.Second),
 				},
 				Tempo: config.ExternalService{
-					Host: config.DefaultTempoHost,
+					Host:    config.DefaultTempoHost,
+					Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
 				},
 				Jaeger: config.ExternalService{
-					Host: config.DefaultJaegerHost,
+					Host:    config.DefaultJaegerHost,
+					Timeout: config.Duration(config.DefaultExternalServiceTimeout * time.Second),
 				},
-				Redis: config.ExternalService{
-					Host: config.DefaultRedisHost,
+				Prometheus: config.Prometheus{
+					Port: config.DefaultPrometheusPort,
+					Path: config.DefaultPrometheusPath,
 				},
-				Token: config.Token{
-					MaxAge: config.DefaultSessionMaxAge,
+				Redis: config.Redis{
+					Host:         config.DefaultRedisHost,
+					DialTimeout:  config.Duration(config.DefaultRedisDialTimeout * time.Second),
+					ReadTimeout:  config.Duration(config.DefaultRedisReadTimeout * time.Second),
+					WriteTimeout: config.Duration(config.DefaultRedisWriteTimeout * time.Second),
+				},
+				OTel: config.OTel{
+					SampleRatio:     config.DefaultOTelSampleRatio,
+					MetricsInterval: config.Duration(config.DefaultOTelMetricsInterval * time.Second),
+					Endpoint:        config.DefaultOTelEndpoint,
+					Protocol:        config.DefaultOTelProtocol,
+					Timeout:         config.Duration(config.DefaultOTelTimeout * time.Second),
+					Traces:          config.OTelExporter{Exporter: config.DefaultOTelExporter},
+					Metrics:         config.OTelExporter{Exporter: config.DefaultOTelExporter},
+					Logs:            config.OTelExporter{Exporter: config.DefaultOTelExporter},
+				},
+				Log: config.Logging{
+					Level:  config.DefaultLogLevel,
+					Format: config.DefaultLogFormat,
+					Output: config.DefaultLogOutput,
+				},
+				Kafka: config.Kafka{
+					DialTimeout: config.Duration(config.DefaultKafkaDialTimeout * time.Second),
+					SASL: config.SASL{
+						Mechanism: config.DefaultKafkaSASLMechanism,
+					},
+				},
+				NATS: config.NATS{
+					ConnectTimeout: config.Duration(config.DefaultNATSConnectTimeout * time.Second),
+				},
+				RabbitMQ: config.RabbitMQ{
+					Host:      config.DefaultRabbitMQHost,
+					Port:      config.DefaultRabbitMQPort,
+					VHost:     config.DefaultRabbitMQVHost,
+					Heartbeat: config.Duration(config.DefaultRabbitMQHeartbeat * time.Second),
 				},
 			}
 
@@ -397,6 +1083,84 @@ func TestLoadContent(t *testing.T) {
 		assert.Equal(t, config.Config{}, cfg)
 		assert.Error(t, err)
 	})
+
+	t.Run("with an error for each invalid value", func(t *testing.T) {
+		cfg, err := LoadContent([]byte("server:\n  port: abc\ntoken:\n  max_age: def\n"))
+		assert.Equal(t, config.Config{}, cfg)
+		assert.EqualError(t, err, "line 2: server.port: yaml: cannot unmarshal !!str `abc` into int\n"+
+			"line 4: token.max_age: yaml: invalid duration \"def\", use a number of seconds or a duration as \"24h\"")
+
+		var typeErr *yaml.TypeError
+		assert.ErrorAs(t, err, &typeErr)
+	})
+
+	t.Run("should populate databases from urls", func(t *testing.T) {
+		cfg, err := LoadContent([]byte("mysql:\n  url: \"username:password@tcp(mysql.domain:3307)/database\"\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "mysql.domain", cfg.MySql.Host)
+		assert.Equal(t, 3307, cfg.MySql.Port)
+		assert.Equal(t, "database", cfg.MySql.Db)
+	})
+}
+
+func TestLoadContentStrict(t *testing.T) {
+	t.Run("should return a valid config without unknown keys", func(t *testing.T) {
+		cfg, err := LoadContentStrict([]byte("server:\n  host: localhost\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", cfg.Server.Host)
+		assert.Equal(t, config.DefaultPostgresPort, cfg.Postgres.Port)
+	})
+
+	t.Run("should return an error with unknown key paths", func(t *testing.T) {
+		cfg, err := LoadContentStrict([]byte(configContentUnknownKeys))
+		assert.Equal(t, config.Config{}, cfg)
+		require.ErrorIs(t, err, config.ErrUnknownKey)
+		assert.EqualError(t, err, "line 5, column 3: postgres.pasword: unknown config key\nline 6, column 1: tracer: unknown config key")
+	})
+
+	t.Run("should resolve merge keys", func(t *testing.T) {
+		content := "postgres: &database\n  host: db.domain\n  user: username\n" +
+			"mysql: {<<: *database, port: 3307}\n"
+
+		cfg, err := LoadContentStrict([]byte(content))
+		require.NoError(t, err)
+		assert.Equal(t, "db.domain", cfg.MySql.Host)
+		assert.Equal(t, "username", cfg.MySql.User)
+		assert.Equal(t, 3307, cfg.MySql.Port)
+	})
+
+	t.Run("should return unknown keys of merged mappings", func(t *testing.T) {
+		content := "postgres: &database\n  host: db.domain\n  hots: db.domain\n" +
+			"mysql:\n  <<: *database\n  port: 3307\n"
+
+		_, err := LoadContentStrict([]byte(content))
+		require.ErrorIs(t, err, config.ErrUnknownKey)
+		assert.Contains(t, err.Error(), "line 3, column 3: postgres.hots: unknown config key")
+	})
+
+	t.Run("should return decoding errors", func(t *testing.T) {
+		cfg, err := LoadContentStrict([]byte(configContentInvalid))
+		assert.Equal(t, config.Config{}, cfg)
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadStrict(t *testing.T) {
+	t.Run("should return an error with unknown key paths", func(t *testing.T) {
+		tempFile := createTempFile(t, configContentUnknownKeys)
+
+		cfg, err := LoadStrict(tempFile.Name())
+		assert.Equal(t, config.Config{}, cfg)
+		assert.ErrorIs(t, err, config.ErrUnknownKey)
+
+		closeFile(t, tempFile)
+	})
+
+	t.Run("file doesn't exist", func(t *testing.T) {
+		cfg, err := LoadStrict("")
+		assert.Equal(t, config.Config{}, cfg)
+		assert.Error(t, err)
+	})
 }
 
 func createTempFile(t *testing.T, fileContent string) *os.File {